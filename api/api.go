@@ -1,6 +1,8 @@
 package api
 
 import (
+	"context"
+	"github.com/thanhxeon2470/beowulf-go/config"
 	"github.com/thanhxeon2470/beowulf-go/transports"
 	"encoding/json"
 )
@@ -8,18 +10,29 @@ import (
 //API plug-in structure
 type API struct {
 	caller transports.Caller
+	scid   string
 }
 
 //NewAPI plug-in initialization
 func NewAPI(caller transports.Caller) *API {
-	return &API{caller}
+	return &API{caller: caller, scid: config.SIDECHAIN_ID}
 }
 
-func (api *API) call(apiID string, method string, params, resp interface{}, scid string) error {
+//Scid returns the sidechain the contract queries are sent to
+func (api *API) Scid() string {
+	return api.scid
+}
+
+//SetScid sets the sidechain the contract queries are sent to
+func (api *API) SetScid(scid string) {
+	api.scid = scid
+}
+
+func (api *API) callContext(ctx context.Context, apiID string, method string, params, resp interface{}, scid string) error {
 	if len(apiID) > 0 {
-		return api.caller.Call("call", []interface{}{apiID, method, params}, resp, scid)
+		return api.caller.CallContext(ctx, "call", []interface{}{apiID, method, params}, resp, scid)
 	}
-	return api.caller.Call("call", []interface{}{method, params}, resp, scid)
+	return api.caller.CallContext(ctx, "call", []interface{}{method, params}, resp, scid)
 }
 
 func (api *API) setCallback(apiID string, method string, callback func(raw json.RawMessage)) error {
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/thanhxeon2470/beowulf-go/config"
+	"github.com/thanhxeon2470/beowulf-go/transports"
+)
+
+//batchCall is a single api request of a batch
+type batchCall struct {
+	method string
+	params interface{}
+	resp   interface{}
+}
+
+// callBatch sends the requests as JSON-RPC batches of at most config.RPC_BATCH_MAX_SIZE calls.
+// Transports that do not implement transports.BatchCaller get the requests one by one.
+// The returned slice holds the error of each request.
+func (api *API) callBatch(ctx context.Context, apiID string, calls []batchCall) ([]error, error) {
+	errs := make([]error, len(calls))
+	batcher, ok := api.caller.(transports.BatchCaller)
+	if !ok {
+		for i, c := range calls {
+			errs[i] = api.callContext(ctx, apiID, c.method, c.params, c.resp, "")
+		}
+		return errs, nil
+	}
+
+	for start := 0; start < len(calls); start += config.RPC_BATCH_MAX_SIZE {
+		end := start + config.RPC_BATCH_MAX_SIZE
+		if end > len(calls) {
+			end = len(calls)
+		}
+
+		batch := make([]transports.BatchElem, 0, end-start)
+		for _, c := range calls[start:end] {
+			batch = append(batch, transports.BatchElem{
+				Method: "call",
+				Args:   []interface{}{apiID, c.method, c.params},
+				Reply:  c.resp,
+			})
+		}
+		if err := batcher.CallBatchContext(ctx, batch, ""); err != nil {
+			return nil, err
+		}
+		for i, elem := range batch {
+			errs[start+i] = elem.Error
+		}
+	}
+	return errs, nil
+}
+
+//GetBlocks fetches the blocks from..to (inclusive) with batched get_block requests
+func (api *API) GetBlocks(from, to uint32) ([]*Block, error) {
+	return api.GetBlocksContext(context.Background(), from, to)
+}
+
+//GetBlocksContext fetches the blocks from..to (inclusive) with batched get_block requests bound to ctx
+func (api *API) GetBlocksContext(ctx context.Context, from, to uint32) ([]*Block, error) {
+	if from > to {
+		return nil, errors.Errorf("invalid block range: %d > %d", from, to)
+	}
+
+	blocks := make([]*Block, 0, to-from+1)
+	calls := make([]batchCall, 0, to-from+1)
+	for num := from; ; num++ {
+		block := &Block{Number: num}
+		blocks = append(blocks, block)
+		calls = append(calls, batchCall{method: "get_block", params: []uint32{num}, resp: block})
+		if num == to {
+			break
+		}
+	}
+
+	errs, err := api.callBatch(ctx, "condenser_api", calls)
+	if err != nil {
+		return nil, err
+	}
+	for i, err := range errs {
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get block %d", blocks[i].Number)
+		}
+	}
+	return blocks, nil
+}
+
+//GetAccountsBatch fetches the accounts with batched get_accounts requests,
+//unknown accounts are left out of the result
+func (api *API) GetAccountsBatch(names []string) (*AccountList, error) {
+	return api.GetAccountsBatchContext(context.Background(), names)
+}
+
+//GetAccountsBatchContext fetches the accounts with batched get_accounts requests bound to ctx
+func (api *API) GetAccountsBatchContext(ctx context.Context, names []string) (*AccountList, error) {
+	lists := make([]AccountList, len(names))
+	calls := make([]batchCall, len(names))
+	for i, name := range names {
+		calls[i] = batchCall{method: "get_accounts", params: [][]string{{name}}, resp: &lists[i]}
+	}
+
+	errs, err := api.callBatch(ctx, "condenser_api", calls)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make(AccountList, 0, len(names))
+	for i, err := range errs {
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get account %s", names[i])
+		}
+		resp = append(resp, lists[i]...)
+	}
+	return &resp, nil
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"github.com/thanhxeon2470/beowulf-go/transports"
 	"github.com/thanhxeon2470/beowulf-go/types"
 	_ "github.com/thanhxeon2470/beowulf-go/types"
@@ -9,37 +10,61 @@ import (
 )
 
 func (api *API) GetVersion() (*Version, error) {
+	return api.GetVersionContext(context.Background())
+}
+
+func (api *API) GetVersionContext(ctx context.Context) (*Version, error) {
 	var resp Version
-	err := api.call("condenser_api", "get_version", transports.EmptyParams, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "get_version", transports.EmptyParams, &resp, "")
 	return &resp, err
 }
 
 //GetConfig api request get_config
 func (api *API) GetConfig() (*Config, error) {
+	return api.GetConfigContext(context.Background())
+}
+
+//GetConfigContext api request get_config bound to ctx
+func (api *API) GetConfigContext(ctx context.Context) (*Config, error) {
 	var resp Config
-	err := api.call("condenser_api", "get_config", transports.EmptyParams, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "get_config", transports.EmptyParams, &resp, "")
 	return &resp, err
 }
 
 //GetDynamicGlobalProperties api request get_dynamic_global_properties
 func (api *API) GetDynamicGlobalProperties() (*DynamicGlobalProperties, error) {
+	return api.GetDynamicGlobalPropertiesContext(context.Background())
+}
+
+//GetDynamicGlobalPropertiesContext api request get_dynamic_global_properties bound to ctx
+func (api *API) GetDynamicGlobalPropertiesContext(ctx context.Context) (*DynamicGlobalProperties, error) {
 	var resp DynamicGlobalProperties
-	err := api.call("condenser_api", "get_dynamic_global_properties", transports.EmptyParams, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "get_dynamic_global_properties", transports.EmptyParams, &resp, "")
 	return &resp, err
 }
 
 //GetBlock api request get_block
 func (api *API) GetBlock(blockNum uint32) (*Block, error) {
+	return api.GetBlockContext(context.Background(), blockNum)
+}
+
+//GetBlockContext api request get_block bound to ctx
+func (api *API) GetBlockContext(ctx context.Context, blockNum uint32) (*Block, error) {
 	var resp Block
-	err := api.call("condenser_api", "get_block", []uint32{blockNum}, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "get_block", []uint32{blockNum}, &resp, "")
 	resp.Number = blockNum
 	return &resp, err
 }
 
 //GetBlockHeader api request get_block_header
 func (api *API) GetBlockHeader(blockNum uint32) (*BlockHeader, error) {
+	return api.GetBlockHeaderContext(context.Background(), blockNum)
+}
+
+//GetBlockHeaderContext api request get_block_header bound to ctx
+func (api *API) GetBlockHeaderContext(ctx context.Context, blockNum uint32) (*BlockHeader, error) {
 	var resp BlockHeader
-	err := api.call("condenser_api", "get_block_header", []uint32{blockNum}, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "get_block_header", []uint32{blockNum}, &resp, "")
 	resp.Number = blockNum
 	return &resp, err
 }
@@ -59,160 +84,265 @@ func (api *API) SetBlockAppliedCallback(notice func(header *BlockHeader, error e
 }
 
 func (api *API) GetSupernodeSchedule() (*SupernodeSchedule, error) {
+	return api.GetSupernodeScheduleContext(context.Background())
+}
+
+func (api *API) GetSupernodeScheduleContext(ctx context.Context) (*SupernodeSchedule, error) {
 	var resp SupernodeSchedule
-	err := api.call("condenser_api", "get_supernode_schedule", transports.EmptyParams, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "get_supernode_schedule", transports.EmptyParams, &resp, "")
 	return &resp, err
 }
 
 func (api *API) GetHardforkVersion() (*string, error) {
+	return api.GetHardforkVersionContext(context.Background())
+}
+
+func (api *API) GetHardforkVersionContext(ctx context.Context) (*string, error) {
 	var resp string
-	err := api.call("condenser_api", "get_hardfork_version", transports.EmptyParams, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "get_hardfork_version", transports.EmptyParams, &resp, "")
 	return &resp, err
 }
 
 func (api *API) GetNextScheduledHardfork() (*ScheduledHardfork, error) {
+	return api.GetNextScheduledHardforkContext(context.Background())
+}
+
+func (api *API) GetNextScheduledHardforkContext(ctx context.Context) (*ScheduledHardfork, error) {
 	var resp ScheduledHardfork
-	err := api.call("condenser_api", "get_next_scheduled_hardfork", transports.EmptyParams, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "get_next_scheduled_hardfork", transports.EmptyParams, &resp, "")
 	return &resp, err
 }
 
 //GetTransaction api request get_transaction
 func (api *API) GetTransaction(trxId string) (*TransactionResponse, error) {
+	return api.GetTransactionContext(context.Background(), trxId)
+}
+
+//GetTransactionContext api request get_transaction bound to ctx
+func (api *API) GetTransactionContext(ctx context.Context, trxId string) (*TransactionResponse, error) {
 	var resp TransactionResponse
-	err := api.call("condenser_api", "get_transaction", []string{trxId}, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "get_transaction", []string{trxId}, &resp, "")
 	//resp.ID = trxId
 	return &resp, err
 }
 
 func (api *API) GetTransactionWithStatus(trxId string) (*TransactionResponse, error) {
+	return api.GetTransactionWithStatusContext(context.Background(), trxId)
+}
+
+func (api *API) GetTransactionWithStatusContext(ctx context.Context, trxId string) (*TransactionResponse, error) {
 	var resp TransactionResponse
-	err := api.call("condenser_api", "get_transaction_with_status", []string{trxId}, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "get_transaction_with_status", []string{trxId}, &resp, "")
 	//resp.ID = trxId
 	return &resp, err
 }
 
 //GetTransactionHex api request get_transaction_hex
 func (api *API) GetTransactionHex(tx *types.Transaction) (string, error) {
+	return api.GetTransactionHexContext(context.Background(), tx)
+}
+
+//GetTransactionHexContext api request get_transaction_hex bound to ctx
+func (api *API) GetTransactionHexContext(ctx context.Context, tx *types.Transaction) (string, error) {
 	var resp string
-	err := api.call("condenser_api", "get_transaction_hex", []interface{}{tx}, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "get_transaction_hex", []interface{}{tx}, &resp, "")
 	return resp, err
 }
 
 //BroadcastTransaction api request broadcast_transaction
 func (api *API) BroadcastTransaction(tx *types.Transaction) (*AsyncBroadcastResponse, error) {
+	return api.BroadcastTransactionContext(context.Background(), tx)
+}
+
+//BroadcastTransactionContext api request broadcast_transaction bound to ctx
+func (api *API) BroadcastTransactionContext(ctx context.Context, tx *types.Transaction) (*AsyncBroadcastResponse, error) {
 	var resp AsyncBroadcastResponse
-	err := api.call("condenser_api", "broadcast_transaction", []interface{}{tx}, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "broadcast_transaction", []interface{}{tx}, &resp, "")
 	return &resp, err
 }
 
 //BroadcastTransactionSynchronous api request broadcast_transaction_synchronous
 func (api *API) BroadcastTransactionSynchronous(tx *types.Transaction) (*BroadcastResponse, error) {
+	return api.BroadcastTransactionSynchronousContext(context.Background(), tx)
+}
+
+//BroadcastTransactionSynchronousContext api request broadcast_transaction_synchronous bound to ctx
+func (api *API) BroadcastTransactionSynchronousContext(ctx context.Context, tx *types.Transaction) (*BroadcastResponse, error) {
 	var resp BroadcastResponse
-	err := api.call("condenser_api", "broadcast_transaction_synchronous", []interface{}{tx}, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "broadcast_transaction_synchronous", []interface{}{tx}, &resp, "")
 	return &resp, err
 }
 
 func (api *API) GetAccounts(account string) (*AccountList, error) {
+	return api.GetAccountsContext(context.Background(), account)
+}
+
+func (api *API) GetAccountsContext(ctx context.Context, account string) (*AccountList, error) {
 	var resp AccountList
-	err := api.call("condenser_api", "get_accounts", [][]string{{account}}, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "get_accounts", [][]string{{account}}, &resp, "")
 	return &resp, err
 }
 
 func (api *API) GetSupernodes(id types.UInt16) (*SupernodeList, error) {
+	return api.GetSupernodesContext(context.Background(), id)
+}
+
+func (api *API) GetSupernodesContext(ctx context.Context, id types.UInt16) (*SupernodeList, error) {
 	var resp SupernodeList
-	err := api.call("condenser_api", "get_supernodes", [][]types.UInt16{{id}}, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "get_supernodes", [][]types.UInt16{{id}}, &resp, "")
 	return &resp, err
 }
 
 func (api *API) GetSupernodeByAccount(account string) (*SupernodeInfo, error) {
+	return api.GetSupernodeByAccountContext(context.Background(), account)
+}
+
+func (api *API) GetSupernodeByAccountContext(ctx context.Context, account string) (*SupernodeInfo, error) {
 	var resp SupernodeInfo
-	err := api.call("condenser_api", "get_supernode_by_account", []string{account}, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "get_supernode_by_account", []string{account}, &resp, "")
 	return &resp, err
 }
 
 func (api *API) GetSupernodeByVote(lowerBound string, limit uint32) (*SupernodeList, error) {
+	return api.GetSupernodeByVoteContext(context.Background(), lowerBound, limit)
+}
+
+func (api *API) GetSupernodeByVoteContext(ctx context.Context, lowerBound string, limit uint32) (*SupernodeList, error) {
 	var resp SupernodeList
-	err := api.call("condenser_api", "get_supernodes_by_vote", []interface{}{lowerBound, limit}, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "get_supernodes_by_vote", []interface{}{lowerBound, limit}, &resp, "")
 	return &resp, err
 }
 
 func (api *API) LookupSupernodeAccounts(lowerBound string, limit uint32) (*[]string, error) {
+	return api.LookupSupernodeAccountsContext(context.Background(), lowerBound, limit)
+}
+
+func (api *API) LookupSupernodeAccountsContext(ctx context.Context, lowerBound string, limit uint32) (*[]string, error) {
 	var resp []string
-	err := api.call("condenser_api", "lookup_supernode_accounts", []interface{}{lowerBound, limit}, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "lookup_supernode_accounts", []interface{}{lowerBound, limit}, &resp, "")
 	return &resp, err
 }
 
 func (api *API) GetSupernodeCount() (*uint64, error) {
+	return api.GetSupernodeCountContext(context.Background())
+}
+
+func (api *API) GetSupernodeCountContext(ctx context.Context) (*uint64, error) {
 	var resp uint64
-	err := api.call("condenser_api", "get_supernode_count", transports.EmptyParams, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "get_supernode_count", transports.EmptyParams, &resp, "")
 	return &resp, err
 }
 
 func (api *API) GetSupernodeVoted(account string) (*SupernodeVoteList, error) {
+	return api.GetSupernodeVotedContext(context.Background(), account)
+}
+
+func (api *API) GetSupernodeVotedContext(ctx context.Context, account string) (*SupernodeVoteList, error) {
 	var resp SupernodeVoteList
-	err := api.call("condenser_api", "get_supernode_voted_by_acc", []string{account}, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "get_supernode_voted_by_acc", []string{account}, &resp, "")
 	return &resp, err
 }
 
 func (api *API) GetKeyReferences(publicKey string) (*[][]string, error) {
+	return api.GetKeyReferencesContext(context.Background(), publicKey)
+}
+
+func (api *API) GetKeyReferencesContext(ctx context.Context, publicKey string) (*[][]string, error) {
 	var resp [][]string
-	err := api.call("condenser_api", "get_key_references", [][]string{{publicKey}}, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "get_key_references", [][]string{{publicKey}}, &resp, "")
 	return &resp, err
 }
 
 func (api *API) ListAccounts(lowerBound string, limit uint32) (*[]string, error) {
+	return api.ListAccountsContext(context.Background(), lowerBound, limit)
+}
+
+func (api *API) ListAccountsContext(ctx context.Context, lowerBound string, limit uint32) (*[]string, error) {
 	var resp []string
-	err := api.call("condenser_api", "lookup_accounts", []interface{}{lowerBound, limit}, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "lookup_accounts", []interface{}{lowerBound, limit}, &resp, "")
 	return &resp, err
 }
 
 func (api *API) GetAccountCount() (*uint64, error) {
+	return api.GetAccountCountContext(context.Background())
+}
+
+func (api *API) GetAccountCountContext(ctx context.Context) (*uint64, error) {
 	var resp uint64
-	err := api.call("condenser_api", "get_account_count", transports.EmptyParams, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "get_account_count", transports.EmptyParams, &resp, "")
 	return &resp, err
 }
 
 func (api *API) GetActiveSupernodes() (*[]string, error) {
+	return api.GetActiveSupernodesContext(context.Background())
+}
+
+func (api *API) GetActiveSupernodesContext(ctx context.Context) (*[]string, error) {
 	var resp []string
-	err := api.call("condenser_api", "get_active_supernodes", transports.EmptyParams, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "get_active_supernodes", transports.EmptyParams, &resp, "")
 	return &resp, err
 }
 
 func (api *API) ListSupernodes(lowerBound string, limit uint32) (*[]string, error) {
+	return api.ListSupernodesContext(context.Background(), lowerBound, limit)
+}
+
+func (api *API) ListSupernodesContext(ctx context.Context, lowerBound string, limit uint32) (*[]string, error) {
 	var resp []string
-	err := api.call("condenser_api", "lookup_supernode_accounts", []interface{}{lowerBound, limit}, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "lookup_supernode_accounts", []interface{}{lowerBound, limit}, &resp, "")
 	return &resp, err
 }
 
 func (api *API) ListTokens() (*TokenList, error) {
+	return api.ListTokensContext(context.Background())
+}
+
+func (api *API) ListTokensContext(ctx context.Context) (*TokenList, error) {
 	var resp TokenList
-	err := api.call("condenser_api", "list_smt_tokens", transports.EmptyParams, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "list_smt_tokens", transports.EmptyParams, &resp, "")
 	return &resp, err
 }
 
 func (api *API) GetTokens(name string) (*TokenList, error) {
+	return api.GetTokensContext(context.Background(), name)
+}
+
+func (api *API) GetTokensContext(ctx context.Context, name string) (*TokenList, error) {
 	var resp TokenList
-	err := api.call("condenser_api", "find_smt_tokens_by_name", []string{name}, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "find_smt_tokens_by_name", []string{name}, &resp, "")
 	return &resp, err
 }
 
 //Get balance
 func (api *API) GetBalance(account, tokenName string, decimals uint8) (*string, error) {
+	return api.GetBalanceContext(context.Background(), account, tokenName, decimals)
+}
+
+//Get balance bound to ctx
+func (api *API) GetBalanceContext(ctx context.Context, account, tokenName string, decimals uint8) (*string, error) {
 	var resp string
 	var symbol types.AssetSymbol
 	symbol.AssetName = tokenName
 	symbol.Decimals = decimals
-	err := api.call("condenser_api", "get_balance", []interface{}{account, symbol}, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "get_balance", []interface{}{account, symbol}, &resp, "")
 	return &resp, err
 }
 
 func (api *API) GetPendingTransactionCount() (*uint64, error) {
+	return api.GetPendingTransactionCountContext(context.Background())
+}
+
+func (api *API) GetPendingTransactionCountContext(ctx context.Context) (*uint64, error) {
 	var resp uint64
-	err := api.call("condenser_api", "get_pending_transaction_count", transports.EmptyParams, &resp, "")
+	err := api.callContext(ctx, "condenser_api", "get_pending_transaction_count", transports.EmptyParams, &resp, "")
 	return &resp, err
 }
 
 func (api *API) GetNFTs(symbol string, limit, offset uint32) (*NFTList, error) {
+	return api.GetNFTsContext(context.Background(), symbol, limit, offset)
+}
+
+func (api *API) GetNFTsContext(ctx context.Context, symbol string, limit, offset uint32) (*NFTList, error) {
 	var resp NFTList
 	var params Params
 	params.Contract = "nft"
@@ -224,11 +354,15 @@ func (api *API) GetNFTs(symbol string, limit, offset uint32) (*NFTList, error) {
 	params.Query = obj
 	params.Limit = limit
 	params.Offset = offset
-	err := api.call("", "find", params, &resp, "s01")
+	err := api.FindContext(ctx, params, &resp)
 	return &resp, err
 }
 
 func (api *API) GetNFTBalance(account string, symbol string, limit, offset uint32) (*NFTInstanceList, error) {
+	return api.GetNFTBalanceContext(context.Background(), account, symbol, limit, offset)
+}
+
+func (api *API) GetNFTBalanceContext(ctx context.Context, account string, symbol string, limit, offset uint32) (*NFTInstanceList, error) {
 	if len(symbol) <= 0 {
 		return nil, errors.New("Symbol can't be null")
 	}
@@ -243,11 +377,15 @@ func (api *API) GetNFTBalance(account string, symbol string, limit, offset uint3
 	params.Query = obj
 	params.Limit = limit
 	params.Offset = offset
-	err := api.call("", "find", params, &resp, "s01")
+	err := api.FindContext(ctx, params, &resp)
 	return &resp, err
 }
 
 func (api *API) GetNFTInstances(symbol string, limit, offset uint32) (*NFTInstanceList, error) {
+	return api.GetNFTInstancesContext(context.Background(), symbol, limit, offset)
+}
+
+func (api *API) GetNFTInstancesContext(ctx context.Context, symbol string, limit, offset uint32) (*NFTInstanceList, error) {
 	if len(symbol) <= 0 {
 		return nil, errors.New("Symbol can't be null")
 	}
@@ -259,21 +397,27 @@ func (api *API) GetNFTInstances(symbol string, limit, offset uint32) (*NFTInstan
 	params.Query = obj
 	params.Limit = limit
 	params.Offset = offset
-	err := api.call("", "find", params, &resp, "s01")
+	err := api.FindContext(ctx, params, &resp)
 	return &resp, err
 }
 
 func (api *API) GetNFTBalanceOfAccount(account string, limit, offset uint32) (map[string]NFTInstanceList, error) {
+	return api.GetNFTBalanceOfAccountContext(context.Background(), account, limit, offset)
+}
+
+//GetNFTBalanceOfAccountContext returns the instances held by the account for every symbol.
+//The returned error is the first error met, see GetNFTHoldingsContext for per-symbol errors.
+func (api *API) GetNFTBalanceOfAccountContext(ctx context.Context, account string, limit, offset uint32) (map[string]NFTInstanceList, error) {
 	result := make(map[string]NFTInstanceList)
 	var res NFTList
 	var params Params
 	params.Contract = "nft"
 	params.Table = "nfts"
-	obj := map[string]interface{}{}
-	params.Query = obj
-	err := api.call("", "find", params, &res, "s01")
+	if err := api.FindAllContext(ctx, params, &res); err != nil {
+		return result, err
+	}
+	var firstErr error
 	for _, element := range res {
-		var resp NFTInstanceList
 		symbol := element.Symbol
 		var instance NFTInstanceList
 		var params Params
@@ -286,35 +430,48 @@ func (api *API) GetNFTBalanceOfAccount(account string, limit, offset uint32) (ma
 		params.Query = obj
 		params.Limit = limit
 		params.Offset = offset
-		err = api.call("", "find", params, &instance, "s01")
-		if err == nil && len(instance) > 0 {
-			resp = append(resp, instance...)
+		err := api.FindContext(ctx, params, &instance)
+		if err != nil && firstErr == nil {
+			firstErr = errors.Wrap(err, symbol)
 		}
-		result[symbol] = resp
+		result[symbol] = instance
 	}
-	return result, err
+	return result, firstErr
 }
+
 func (api *API) GetLatestNFTBlock() (*NFTBlock, error) {
+	return api.GetLatestNFTBlockContext(context.Background())
+}
+
+func (api *API) GetLatestNFTBlockContext(ctx context.Context) (*NFTBlock, error) {
 	var resp NFTBlock
-	err := api.call("", "getLatestBlockInfo", transports.EmptyParams, &resp, "s01")
+	err := api.callContext(ctx, "", "getLatestBlockInfo", transports.EmptyParams, &resp, api.scid)
 	//resp.Number = blockNum
 	return &resp, err
 }
 
 func (api *API) GetNFTBlock(blockNum uint32) (*NFTBlock, error) {
+	return api.GetNFTBlockContext(context.Background(), blockNum)
+}
+
+func (api *API) GetNFTBlockContext(ctx context.Context, blockNum uint32) (*NFTBlock, error) {
 	var resp NFTBlock
 	var params BlockParams
 	params.BlockNumber = blockNum
-	err := api.call("", "getBlockInfo", params, &resp, "s01")
+	err := api.callContext(ctx, "", "getBlockInfo", params, &resp, api.scid)
 	//resp.Number = blockNum
 	return &resp, err
 }
 
 func (api *API) GetNFTTransaction(trxId string) (*NFTTransaction, error) {
+	return api.GetNFTTransactionContext(context.Background(), trxId)
+}
+
+func (api *API) GetNFTTransactionContext(ctx context.Context, trxId string) (*NFTTransaction, error) {
 	var resp NFTTransaction
 	var params TransactionParams
 	params.Txid = trxId
-	err := api.call("", "getTransactionInfo", params, &resp, "s01")
+	err := api.callContext(ctx, "", "getTransactionInfo", params, &resp, api.scid)
 	//resp.ID = trxId
 	return &resp, err
 }
@@ -0,0 +1,213 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/thanhxeon2470/beowulf-go/config"
+)
+
+var (
+	//ErrNotFound is returned by FindOne when no record matches the query
+	ErrNotFound = errors.New("record not found")
+	//ErrIteratorDone is returned by FindIterator.Next after the last record
+	ErrIteratorDone = errors.New("no more records")
+)
+
+//Query filters the records of a contract table, e.g.
+//	Query{}.Eq("account", "alice").In("symbol", "ART", "CARD").Range("_id", 10, 20)
+type Query map[string]interface{}
+
+//Eq matches the records whose field equals value.
+func (q Query) Eq(field string, value interface{}) Query {
+	if q == nil {
+		q = Query{}
+	}
+	q[field] = value
+	return q
+}
+
+//Ne matches the records whose field differs from value.
+func (q Query) Ne(field string, value interface{}) Query {
+	return q.op(field, "$ne", value)
+}
+
+//In matches the records whose field is one of the values.
+func (q Query) In(field string, values ...interface{}) Query {
+	return q.op(field, "$in", values)
+}
+
+//Nin matches the records whose field is none of the values.
+func (q Query) Nin(field string, values ...interface{}) Query {
+	return q.op(field, "$nin", values)
+}
+
+//Gt matches the records whose field is greater than value.
+func (q Query) Gt(field string, value interface{}) Query {
+	return q.op(field, "$gt", value)
+}
+
+//Gte matches the records whose field is greater than or equal to value.
+func (q Query) Gte(field string, value interface{}) Query {
+	return q.op(field, "$gte", value)
+}
+
+//Lt matches the records whose field is less than value.
+func (q Query) Lt(field string, value interface{}) Query {
+	return q.op(field, "$lt", value)
+}
+
+//Lte matches the records whose field is less than or equal to value.
+func (q Query) Lte(field string, value interface{}) Query {
+	return q.op(field, "$lte", value)
+}
+
+//Range matches the records whose field is between from and to, both inclusive.
+func (q Query) Range(field string, from, to interface{}) Query {
+	return q.Gte(field, from).Lte(field, to)
+}
+
+// op adds the operator to the conditions on field, replacing an equality
+func (q Query) op(field, op string, value interface{}) Query {
+	if q == nil {
+		q = Query{}
+	}
+	ops, ok := q[field].(map[string]interface{})
+	if !ok {
+		ops = map[string]interface{}{}
+		q[field] = ops
+	}
+	ops[op] = value
+	return q
+}
+
+//Index sorts the records of a find request by an indexed field
+type Index struct {
+	Index      string `json:"index"`
+	Descending bool   `json:"descending"`
+}
+
+type findOneParams struct {
+	Contract string `json:"contract"`
+	Table    string `json:"table"`
+	Query    Query  `json:"query"`
+}
+
+// emptyQuery replaces a missing query, the node expects an object
+func emptyQuery(query interface{}) interface{} {
+	switch q := query.(type) {
+	case nil:
+		return Query{}
+	case Query:
+		if q == nil {
+			return Query{}
+		}
+	case map[string]interface{}:
+		if q == nil {
+			return Query{}
+		}
+	}
+	return query
+}
+
+//FindOne decodes into result the first record of the contract table matching the query.
+//It returns ErrNotFound when there is none.
+func (api *API) FindOne(contract, table string, query Query, result interface{}) error {
+	return api.FindOneContext(context.Background(), contract, table, query, result)
+}
+
+//FindOneContext decodes into result the first record of the contract table matching the query.
+//It returns ErrNotFound when there is none.
+func (api *API) FindOneContext(ctx context.Context, contract, table string, query Query, result interface{}) error {
+	if query == nil {
+		query = Query{}
+	}
+	var raw json.RawMessage
+	params := findOneParams{Contract: contract, Table: table, Query: query}
+	if err := api.callContext(ctx, "", "findOne", params, &raw, api.scid); err != nil {
+		return err
+	}
+	if len(raw) == 0 || string(raw) == "null" {
+		return ErrNotFound
+	}
+	return json.Unmarshal(raw, result)
+}
+
+//Find decodes into result, a pointer to a slice, one page of the records matching params.
+func (api *API) Find(params Params, result interface{}) error {
+	return api.FindContext(context.Background(), params, result)
+}
+
+//FindContext decodes into result, a pointer to a slice, one page of the records matching params.
+func (api *API) FindContext(ctx context.Context, params Params, result interface{}) error {
+	params.Query = emptyQuery(params.Query)
+	return api.callContext(ctx, "", "find", params, result, api.scid)
+}
+
+//FindAll decodes into result, a pointer to a slice, all the records matching params
+//fetched page by page.
+func (api *API) FindAll(params Params, result interface{}) error {
+	return api.FindAllContext(context.Background(), params, result)
+}
+
+//FindAllContext decodes into result, a pointer to a slice, all the records matching params
+//fetched page by page.
+func (api *API) FindAllContext(ctx context.Context, params Params, result interface{}) error {
+	it := api.FindIter(params)
+	records := []json.RawMessage{}
+	for {
+		var record json.RawMessage
+		err := it.Next(ctx, &record)
+		if err == ErrIteratorDone {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		records = append(records, record)
+	}
+	data, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+//FindIterator reads the records matching a find request page by page
+type FindIterator struct {
+	api    *API
+	params Params
+	page   []json.RawMessage
+	done   bool
+}
+
+//FindIter returns an iterator over the records matching params starting at params.Offset.
+//params.Limit is the page size, config.SIDECHAIN_FIND_PAGE_SIZE when zero or above it: the sidechain
+//never answers more records at once, a shorter page ends the iteration.
+func (api *API) FindIter(params Params) *FindIterator {
+	if params.Limit == 0 || params.Limit > config.SIDECHAIN_FIND_PAGE_SIZE {
+		params.Limit = config.SIDECHAIN_FIND_PAGE_SIZE
+	}
+	return &FindIterator{api: api, params: params}
+}
+
+//Next decodes the next record into v, fetching the next page when needed.
+//It returns ErrIteratorDone after the last record.
+func (it *FindIterator) Next(ctx context.Context, v interface{}) error {
+	for len(it.page) == 0 {
+		if it.done {
+			return ErrIteratorDone
+		}
+		var page []json.RawMessage
+		if err := it.api.FindContext(ctx, it.params, &page); err != nil {
+			return err
+		}
+		it.params.Offset += uint32(len(page))
+		it.done = uint32(len(page)) < it.params.Limit
+		it.page = page
+	}
+	record := it.page[0]
+	it.page = it.page[1:]
+	return json.Unmarshal(record, v)
+}
@@ -0,0 +1,175 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/thanhxeon2470/beowulf-go/config"
+)
+
+// fakeTable answers find and findOne requests from in-memory tables,
+// queries are matched on plain values and $in only
+type fakeTable struct {
+	mutex   sync.Mutex
+	records []map[string]interface{}
+	tables  map[string][]map[string]interface{}
+	fail    map[string]bool
+	calls   []Params
+	scids   []string
+}
+
+func (f *fakeTable) Call(method string, args []interface{}, reply interface{}, scid string) error {
+	return f.CallContext(context.Background(), method, args, reply, scid)
+}
+
+func (f *fakeTable) CallContext(ctx context.Context, method string, args []interface{}, reply interface{}, scid string) error {
+	data, _ := json.Marshal(args[1])
+	var params Params
+	if err := json.Unmarshal(data, &params); err != nil {
+		return err
+	}
+	f.mutex.Lock()
+	f.scids = append(f.scids, scid)
+	f.calls = append(f.calls, params)
+	f.mutex.Unlock()
+	if f.fail[params.Table] {
+		return errors.New("table unavailable")
+	}
+
+	records := f.records
+	if f.tables != nil {
+		records = f.tables[params.Table]
+	}
+	// decoded like the query, e.g. numbers as float64
+	data, _ = json.Marshal(records)
+	records = nil
+	json.Unmarshal(data, &records)
+	var matching []map[string]interface{}
+	for _, record := range records {
+		if matches(record, params.Query) {
+			matching = append(matching, record)
+		}
+	}
+
+	var result interface{}
+	switch args[0] {
+	case "findOne":
+		if len(matching) > 0 {
+			result = matching[0]
+		}
+	case "find":
+		// the sidechain caps the page size
+		limit := params.Limit
+		if limit > config.SIDECHAIN_FIND_PAGE_SIZE {
+			limit = config.SIDECHAIN_FIND_PAGE_SIZE
+		}
+		page := []map[string]interface{}{}
+		for i := params.Offset; i < params.Offset+limit && int(i) < len(matching); i++ {
+			page = append(page, matching[i])
+		}
+		result = page
+	}
+	data, _ = json.Marshal(result)
+	if string(data) == "null" {
+		return nil
+	}
+	return json.Unmarshal(data, reply)
+}
+
+func matches(record map[string]interface{}, query interface{}) bool {
+	fields, _ := query.(map[string]interface{})
+	for field, condition := range fields {
+		ops, ok := condition.(map[string]interface{})
+		if !ok {
+			if record[field] != condition {
+				return false
+			}
+			continue
+		}
+		if values, ok := ops["$in"].([]interface{}); ok {
+			found := false
+			for _, value := range values {
+				found = found || record[field] == value
+			}
+			if !found {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (f *fakeTable) SetCallback(api string, method string, callback func(raw json.RawMessage)) error {
+	return nil
+}
+
+func TestQuery(t *testing.T) {
+	query := Query{}.Eq("account", "alice").In("symbol", "ART", "CARD").Range("_id", 10, 20).Ne("burned", true)
+	data, err := json.Marshal(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"_id":{"$gte":10,"$lte":20},"account":"alice","burned":{"$ne":true},"symbol":{"$in":["ART","CARD"]}}`
+	if string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+}
+
+func TestFind(t *testing.T) {
+	type record struct {
+		Id      int    `json:"_id"`
+		Account string `json:"account"`
+	}
+	table := &fakeTable{}
+	for i := 0; i < 5; i++ {
+		table.records = append(table.records, map[string]interface{}{"_id": i, "account": "alice"})
+	}
+	api := NewAPI(table)
+	api.SetScid("s02")
+
+	var records []record
+	params := Params{Contract: "nft", Table: "ARTinstances", Limit: 2, Indexes: []Index{{Index: "_id"}}}
+	if err := api.FindAll(params, &records); err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 5 || records[4].Id != 4 {
+		t.Errorf("records %+v", records)
+	}
+	if len(table.calls) != 3 || table.calls[2].Offset != 4 || len(table.calls[2].Indexes) != 1 {
+		t.Errorf("calls %+v", table.calls)
+	}
+	for _, scid := range table.scids {
+		if scid != "s02" {
+			t.Errorf("scid %q", scid)
+		}
+	}
+
+	// a page size above the cap of the sidechain
+	table.calls = nil
+	for i := 5; i < 2500; i++ {
+		table.records = append(table.records, map[string]interface{}{"_id": i, "account": "alice"})
+	}
+	records = nil
+	params.Limit = 5000
+	if err := api.FindAll(params, &records); err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2500 || records[2499].Id != 2499 {
+		t.Errorf("%d records", len(records))
+	}
+	if len(table.calls) != 3 || table.calls[0].Limit != config.SIDECHAIN_FIND_PAGE_SIZE {
+		t.Errorf("%d calls, limit %d", len(table.calls), table.calls[0].Limit)
+	}
+
+	var one record
+	if err := api.FindOne("nft", "ARTinstances", Query{}.Eq("_id", 0), &one); err != nil || one.Account != "alice" {
+		t.Errorf("FindOne: %+v, %v", one, err)
+	}
+	table.records = nil
+	if err := api.FindOne("nft", "ARTinstances", nil, &one); err != ErrNotFound {
+		t.Errorf("FindOne: %v, want ErrNotFound", err)
+	}
+}
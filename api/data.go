@@ -218,6 +218,16 @@ type TokenInfo struct {
 
 type TokenList []TokenInfo
 
+//The statuses of a transaction answered by GetTransactionWithStatus
+const (
+	TransactionStatusUnknown                 = "unknown"
+	TransactionStatusWithinMempool           = "within_mempool"
+	TransactionStatusWithinReversibleBlock   = "within_reversible_block"
+	TransactionStatusWithinIrreversibleBlock = "within_irreversible_block"
+	TransactionStatusExpiredReversible       = "expired_reversible"
+	TransactionStatusExpiredIrreversible     = "expired_irreversible"
+)
+
 type TransactionResponse struct {
 	RefBlockNum    *types.UInt16     `json:"ref_block_num"`
 	RefBlockPrefix *types.UInt32     `json:"ref_block_prefix"`
@@ -246,14 +256,23 @@ type NFTTransaction struct {
 }
 
 type NFT struct {
-	Id                        *types.UInt16 `json:"_id"`
-	Issuer                    string        `json:"issuer"`
-	Symbol                    string        `json:"symbol"`
-	Name                      string        `json:"name"`
-	Metadata                  string        `json:"metadata"`
-	MaxSupply                 *types.Int64  `json:"maxSupply"`
-	Supply                    *types.Int64  `json:"supply"`
-	AuthorizedIssuingAccounts []string      `json:"authorizedIssuingAccounts"`
+	Id                        *types.UInt16                    `json:"_id"`
+	Issuer                    string                           `json:"issuer"`
+	Symbol                    string                           `json:"symbol"`
+	Name                      string                           `json:"name"`
+	OrgName                   string                           `json:"orgName"`
+	Metadata                  string                           `json:"metadata"`
+	MaxSupply                 *types.Int64                     `json:"maxSupply"`
+	Supply                    *types.Int64                     `json:"supply"`
+	AuthorizedIssuingAccounts []string                         `json:"authorizedIssuingAccounts"`
+	Properties                map[string]NFTPropertyDefinition `json:"properties"`
+}
+
+//NFTPropertyDefinition is a property declared on the instances of an NFT symbol
+type NFTPropertyDefinition struct {
+	Type                      string   `json:"type"`
+	IsReadOnly                bool     `json:"isReadOnly"`
+	AuthorizedEditingAccounts []string `json:"authorizedEditingAccounts"`
 }
 
 type NFTList []NFT
@@ -264,6 +283,7 @@ type Params struct {
 	Query    interface{} `json:"query"`
 	Limit    uint32      `json:"limit"`
 	Offset   uint32      `json:"offset"`
+	Indexes  []Index     `json:"indexes,omitempty"`
 }
 
 type BlockParams struct {
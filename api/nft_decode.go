@@ -0,0 +1,234 @@
+package api
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/thanhxeon2470/beowulf-go/nft"
+)
+
+//DecodeMetadata decodes the metadata of the symbol, empty when none is set.
+func (n *NFT) DecodeMetadata() (*nft.Metadata, error) {
+	var metadata nft.Metadata
+	if err := n.DecodeMetadataInto(&metadata); err != nil {
+		return nil, err
+	}
+	return &metadata, nil
+}
+
+//DecodeMetadataInto decodes the metadata of the symbol into v, e.g. a struct with custom fields.
+func (n *NFT) DecodeMetadataInto(v interface{}) error {
+	if len(n.Metadata) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(n.Metadata), v); err != nil {
+		return errors.Wrapf(err, "%s metadata", n.Symbol)
+	}
+	return nil
+}
+
+//NFTProperties are the properties of an instance checked against the definition of its symbol.
+//Numbers are float64, strings string and booleans bool.
+type NFTProperties map[string]interface{}
+
+//GetString returns the string property name, "" when it is not set.
+func (p NFTProperties) GetString(name string) (string, error) {
+	value, ok := p[name]
+	if !ok {
+		return "", nil
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", errors.Errorf("property %s is not a string", name)
+	}
+	return s, nil
+}
+
+//GetNumber returns the number property name, 0 when it is not set.
+func (p NFTProperties) GetNumber(name string) (float64, error) {
+	value, ok := p[name]
+	if !ok {
+		return 0, nil
+	}
+	f, ok := value.(float64)
+	if !ok {
+		return 0, errors.Errorf("property %s is not a number", name)
+	}
+	return f, nil
+}
+
+//GetBool returns the boolean property name, false when it is not set.
+func (p NFTProperties) GetBool(name string) (bool, error) {
+	value, ok := p[name]
+	if !ok {
+		return false, nil
+	}
+	b, ok := value.(bool)
+	if !ok {
+		return false, errors.Errorf("property %s is not a boolean", name)
+	}
+	return b, nil
+}
+
+// propertyValue converts the decoded JSON value to the Go type of the property type
+func propertyValue(propertyType nft.PropertyType, value interface{}) (interface{}, bool) {
+	switch propertyType {
+	case nft.PropertyNumber:
+		switch v := value.(type) {
+		case float64:
+			return v, true
+		case json.Number:
+			f, err := v.Float64()
+			return f, err == nil
+		}
+	case nft.PropertyString:
+		s, ok := value.(string)
+		return s, ok
+	case nft.PropertyBoolean:
+		b, ok := value.(bool)
+		return b, ok
+	}
+	return nil, false
+}
+
+//DecodeProperties returns the properties of the instance of the symbol, checking each one
+//is declared by the symbol with the type of its value.
+func (n *NFT) DecodeProperties(instance *NFTInstance) (NFTProperties, error) {
+	properties := NFTProperties{}
+	if instance.Properties == nil {
+		return properties, nil
+	}
+	values, ok := instance.Properties.(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("%s instance properties are not an object", n.Symbol)
+	}
+	for name, value := range values {
+		definition, ok := n.Properties[name]
+		if !ok {
+			return nil, errors.Errorf("%s has no property %s", n.Symbol, name)
+		}
+		converted, ok := propertyValue(nft.PropertyType(definition.Type), value)
+		if !ok {
+			return nil, errors.Errorf("%s property %s is not a %s: %v", n.Symbol, name, definition.Type, value)
+		}
+		properties[name] = converted
+	}
+	return properties, nil
+}
+
+//DecodeInstance checks the properties of the instance against the symbol like DecodeProperties
+//and decodes them into v, a struct with a json tag per property.
+func (n *NFT) DecodeInstance(instance *NFTInstance, v interface{}) error {
+	properties, err := n.DecodeProperties(instance)
+	if err != nil {
+		return err
+	}
+	return decodeValue(properties, v)
+}
+
+//DecodeProperties decodes the properties of the instance into v without checking them.
+func (i *NFTInstance) DecodeProperties(v interface{}) error {
+	if i.Properties == nil {
+		return nil
+	}
+	return decodeValue(i.Properties, v)
+}
+
+func decodeValue(value interface{}, v interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+//NFTEvent is an event emitted by a contract while executing a sidechain transaction
+type NFTEvent struct {
+	Contract string          `json:"contract"`
+	Event    string          `json:"event"`
+	Data     json.RawMessage `json:"data"`
+}
+
+//Decode decodes the data of the event into v.
+func (e *NFTEvent) Decode(v interface{}) error {
+	if len(e.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(e.Data, v)
+}
+
+//NFTLogs are the events and errors logged by a sidechain transaction
+type NFTLogs struct {
+	Events []NFTEvent `json:"events"`
+	Errors []string   `json:"errors"`
+}
+
+//NFTActionError is returned for a sidechain transaction whose action failed
+type NFTActionError struct {
+	TransactionId string
+	Contract      string
+	Action        string
+	Errors        []string
+}
+
+func (e *NFTActionError) Error() string {
+	return "sidechain transaction " + e.TransactionId + " " + e.Contract + "." + e.Action +
+		" failed: " + strings.Join(e.Errors, "; ")
+}
+
+//ParseLogs decodes the logs of the transaction.
+func (t *NFTTransaction) ParseLogs() (*NFTLogs, error) {
+	var logs NFTLogs
+	if len(t.Logs) == 0 {
+		return &logs, nil
+	}
+	if err := json.Unmarshal([]byte(t.Logs), &logs); err != nil {
+		return nil, errors.Wrapf(err, "transaction %s logs", t.TransactionId)
+	}
+	return &logs, nil
+}
+
+//Events returns the events of the transaction emitted by the contract, all of them when contract is "".
+func (t *NFTTransaction) Events(contract string) ([]NFTEvent, error) {
+	logs, err := t.ParseLogs()
+	if err != nil {
+		return nil, err
+	}
+	var events []NFTEvent
+	for _, event := range logs.Events {
+		if contract == "" || event.Contract == contract {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+//Err returns an *NFTActionError when the sidechain logged errors for the transaction,
+//i.e. its action did not succeed.
+func (t *NFTTransaction) Err() error {
+	logs, err := t.ParseLogs()
+	if err != nil {
+		return err
+	}
+	if len(logs.Errors) == 0 {
+		return nil
+	}
+	return &NFTActionError{
+		TransactionId: t.TransactionId,
+		Contract:      t.Contract,
+		Action:        t.Action,
+		Errors:        logs.Errors,
+	}
+}
+
+//DecodePayload decodes the payload of the transaction into v, e.g. the matching nft package payload.
+func (t *NFTTransaction) DecodePayload(v interface{}) error {
+	if len(t.Payload) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(t.Payload), v); err != nil {
+		return errors.Wrapf(err, "transaction %s payload", t.TransactionId)
+	}
+	return nil
+}
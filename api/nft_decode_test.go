@@ -0,0 +1,89 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNFTDecodeProperties(t *testing.T) {
+	var definition NFT
+	err := json.Unmarshal([]byte(`{"symbol":"ART","metadata":"{\"url\":\"https://art.example\",\"image\":\"art.png\"}",
+		"properties":{"color":{"type":"string"},"level":{"type":"number"},"rare":{"type":"boolean"}}}`), &definition)
+	if err != nil {
+		t.Fatal(err)
+	}
+	metadata, err := definition.DecodeMetadata()
+	if err != nil || metadata.URL != "https://art.example" || metadata.Image != "art.png" {
+		t.Errorf("metadata %+v, %v", metadata, err)
+	}
+
+	var instance NFTInstance
+	if err := json.Unmarshal([]byte(`{"_id":1,"account":"alice","properties":{"color":"red","level":3,"rare":true}}`), &instance); err != nil {
+		t.Fatal(err)
+	}
+	properties, err := definition.DecodeProperties(&instance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if level, err := properties.GetNumber("level"); err != nil || level != 3 {
+		t.Errorf("level %v, %v", level, err)
+	}
+	if _, err := properties.GetBool("color"); err == nil {
+		t.Error("color read as a boolean")
+	}
+
+	var art struct {
+		Color string `json:"color"`
+		Level int    `json:"level"`
+		Rare  bool   `json:"rare"`
+	}
+	if err := definition.DecodeInstance(&instance, &art); err != nil || art.Color != "red" || art.Level != 3 || !art.Rare {
+		t.Errorf("instance %+v, %v", art, err)
+	}
+
+	instance.Properties = map[string]interface{}{"level": "high"}
+	if _, err := definition.DecodeProperties(&instance); err == nil {
+		t.Error("no error for a string level")
+	}
+	instance.Properties = map[string]interface{}{"size": 1.0}
+	if _, err := definition.DecodeProperties(&instance); err == nil {
+		t.Error("no error for an undeclared property")
+	}
+}
+
+func TestNFTTransactionLogs(t *testing.T) {
+	ok := NFTTransaction{
+		TransactionId: "a1",
+		Contract:      "nft",
+		Action:        "transfer",
+		Payload:       `{"to":"bob","nfts":[{"symbol":"ART","ids":["1"]}]}`,
+		Logs:          `{"events":[{"contract":"nft","event":"transfer","data":{"from":"alice","to":"bob","symbol":"ART","id":"1"}}]}`,
+	}
+	if err := ok.Err(); err != nil {
+		t.Fatal(err)
+	}
+	events, err := ok.Events("nft")
+	if err != nil || len(events) != 1 {
+		t.Fatalf("events %+v, %v", events, err)
+	}
+	var transfer struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+	}
+	if err := events[0].Decode(&transfer); err != nil || transfer.From != "alice" || transfer.To != "bob" {
+		t.Errorf("event %+v, %v", transfer, err)
+	}
+	var payload struct {
+		To string `json:"to"`
+	}
+	if err := ok.DecodePayload(&payload); err != nil || payload.To != "bob" {
+		t.Errorf("payload %+v, %v", payload, err)
+	}
+
+	failed := NFTTransaction{TransactionId: "a2", Contract: "nft", Action: "issue", Logs: `{"errors":["symbol does not exist"]}`}
+	err = failed.Err()
+	actionErr, isActionErr := err.(*NFTActionError)
+	if !isActionErr || actionErr.Errors[0] != "symbol does not exist" {
+		t.Errorf("error %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/thanhxeon2470/beowulf-go/config"
+)
+
+//NFTHoldings are the instances of an NFT symbol held by an account
+type NFTHoldings struct {
+	Symbol string
+	//Definition of the symbol, set with WithDefinitions
+	Definition *NFT
+	Instances  NFTInstanceList
+	//Err is set when the instances of the symbol could not be fetched
+	Err error
+}
+
+//HoldingsOption configures GetNFTHoldings.
+type HoldingsOption func(*holdingsOptions)
+
+type holdingsOptions struct {
+	symbols         []string
+	concurrency     int
+	pageSize        uint32
+	withDefinitions bool
+}
+
+//WithSymbols restricts the holdings to the given symbols.
+func WithSymbols(symbols ...string) HoldingsOption {
+	return func(o *holdingsOptions) {
+		o.symbols = append(o.symbols, symbols...)
+	}
+}
+
+//WithConcurrency sets how many instance tables are fetched at once, config.NFT_HOLDINGS_CONCURRENCY by default.
+func WithConcurrency(n int) HoldingsOption {
+	return func(o *holdingsOptions) {
+		o.concurrency = n
+	}
+}
+
+//WithPageSize sets the limit of each find request, config.SIDECHAIN_FIND_PAGE_SIZE by default and at most.
+func WithPageSize(n uint32) HoldingsOption {
+	return func(o *holdingsOptions) {
+		o.pageSize = n
+	}
+}
+
+//WithDefinitions joins the definition of its symbol (name, metadata, properties) to each holding.
+func WithDefinitions() HoldingsOption {
+	return func(o *holdingsOptions) {
+		o.withDefinitions = true
+	}
+}
+
+//GetNFTHoldings returns the NFT instances held by the account grouped by symbol.
+func (api *API) GetNFTHoldings(account string, opts ...HoldingsOption) ([]NFTHoldings, error) {
+	return api.GetNFTHoldingsContext(context.Background(), account, opts...)
+}
+
+//GetNFTHoldingsContext returns the NFT instances held by the account grouped by symbol.
+//The symbols are read page by page from the nfts table while their instance tables are fetched
+//concurrently. Symbols the account holds nothing of are left out, a symbol whose instances could
+//not be fetched is returned with its Err set. The returned error is only set when the symbols
+//could not be listed or ctx is done.
+func (api *API) GetNFTHoldingsContext(ctx context.Context, account string, opts ...HoldingsOption) ([]NFTHoldings, error) {
+	if len(account) <= 0 {
+		return nil, errors.New("Account can't be null")
+	}
+	o := holdingsOptions{
+		concurrency: config.NFT_HOLDINGS_CONCURRENCY,
+		pageSize:    config.SIDECHAIN_FIND_PAGE_SIZE,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.concurrency < 1 {
+		o.concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan *NFTHoldings)
+	var wg sync.WaitGroup
+	for i := 0; i < o.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for h := range jobs {
+				params := Params{
+					Contract: "nft",
+					Table:    h.Symbol + "instances",
+					Query:    Query{}.Eq("account", account),
+					Limit:    o.pageSize,
+				}
+				h.Err = api.FindAllContext(ctx, params, &h.Instances)
+			}
+		}()
+	}
+
+	query := Query{}
+	if len(o.symbols) > 0 {
+		symbols := make([]interface{}, 0, len(o.symbols))
+		for _, symbol := range o.symbols {
+			symbols = append(symbols, symbol)
+		}
+		query = query.In("symbol", symbols...)
+	}
+	it := api.FindIter(Params{Contract: "nft", Table: "nfts", Query: query, Limit: o.pageSize})
+
+	var all []*NFTHoldings
+	var err error
+	for err == nil {
+		var definition NFT
+		if err = it.Next(ctx, &definition); err != nil {
+			break
+		}
+		h := &NFTHoldings{Symbol: definition.Symbol}
+		if o.withDefinitions {
+			h.Definition = &definition
+		}
+		all = append(all, h)
+		select {
+		case jobs <- h:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if err != ErrIteratorDone {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	holdings := make([]NFTHoldings, 0, len(all))
+	for _, h := range all {
+		if h.Err != nil || len(h.Instances) > 0 {
+			holdings = append(holdings, *h)
+		}
+	}
+	return holdings, nil
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"testing"
+)
+
+func TestGetNFTHoldings(t *testing.T) {
+	table := &fakeTable{
+		tables: map[string][]map[string]interface{}{
+			"nfts": {
+				{"_id": 1, "symbol": "ART", "name": "Art", "properties": map[string]interface{}{"color": map[string]interface{}{"type": "string"}}},
+				{"_id": 2, "symbol": "CARD", "name": "Cards"},
+				{"_id": 3, "symbol": "GAME", "name": "Games"},
+				{"_id": 4, "symbol": "PET", "name": "Pets"},
+			},
+			"ARTinstances": {
+				{"_id": 1, "account": "alice"},
+				{"_id": 2, "account": "bob"},
+				{"_id": 3, "account": "alice"},
+				{"_id": 4, "account": "alice"},
+			},
+			"CARDinstances": {
+				{"_id": 1, "account": "bob"},
+			},
+			"PETinstances": {
+				{"_id": 1, "account": "alice"},
+			},
+		},
+		fail: map[string]bool{"GAMEinstances": true},
+	}
+	api := NewAPI(table)
+
+	holdings, err := api.GetNFTHoldings("alice", WithPageSize(2), WithConcurrency(2), WithDefinitions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(holdings) != 3 {
+		t.Fatalf("holdings %+v", holdings)
+	}
+	art, game, pet := holdings[0], holdings[1], holdings[2]
+	if art.Symbol != "ART" || len(art.Instances) != 3 || art.Err != nil {
+		t.Errorf("ART %+v", art)
+	}
+	if art.Definition == nil || art.Definition.Name != "Art" || art.Definition.Properties["color"].Type != "string" {
+		t.Errorf("ART definition %+v", art.Definition)
+	}
+	if game.Symbol != "GAME" || game.Err == nil {
+		t.Errorf("GAME %+v", game)
+	}
+	if pet.Symbol != "PET" || len(pet.Instances) != 1 || pet.Definition.Name != "Pets" {
+		t.Errorf("PET %+v", pet)
+	}
+
+	holdings, err = api.GetNFTHoldings("alice", WithSymbols("PET"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(holdings) != 1 || holdings[0].Symbol != "PET" || holdings[0].Definition != nil {
+		t.Errorf("holdings %+v", holdings)
+	}
+}
@@ -3,11 +3,13 @@ package client
 import (
 	"github.com/thanhxeon2470/beowulf-go/api"
 	"github.com/thanhxeon2470/beowulf-go/config"
+	"github.com/thanhxeon2470/beowulf-go/transactions"
 	"github.com/thanhxeon2470/beowulf-go/transports"
 	"github.com/thanhxeon2470/beowulf-go/transports/http"
 	"github.com/thanhxeon2470/beowulf-go/transports/websocket"
 	"github.com/pkg/errors"
 	"net/url"
+	"sync"
 )
 
 var (
@@ -28,33 +30,75 @@ type Client struct {
 
 	// Current private keys for operations
 	CurrentKeys *Keys
+
+	// Signers used instead of CurrentKeys when set
+	signers []transactions.Signer
+
+	// Wallet used by LoadWallet, SetPassword, Unlock and ImportKey
+	walletMutex sync.Mutex
+	wallet      *Wallet
+}
+
+// Option configures the Client created by NewClient.
+type Option func(*options)
+
+type options struct {
+	httpOptions      []http.Option
+	websocketOptions []websocket.Option
+}
+
+// WithHTTPOptions passes the given options to the HTTP transport, e.g. pool sizes or timeouts.
+// They are ignored for websocket URLs.
+func WithHTTPOptions(opts ...http.Option) Option {
+	return func(o *options) {
+		o.httpOptions = append(o.httpOptions, opts...)
+	}
+}
+
+// WithWebsocketOptions passes the given options to the websocket transport, e.g. reconnecting.
+// They are ignored for HTTP URLs.
+func WithWebsocketOptions(opts ...websocket.Option) Option {
+	return func(o *options) {
+		o.websocketOptions = append(o.websocketOptions, opts...)
+	}
 }
 
 // NewClient creates a new RPC client that use the given CallCloser internally.
 // Initialize only server present API. Absent API initialized as nil value.
-func NewClient(s string, isTestNet bool) (*Client, error) {
+func NewClient(s string, isTestNet bool, opts ...Option) (*Client, error) {
 	// Parse URL
 	u, err := url.Parse(s)
 	if err != nil {
 		return nil, err
 	}
 
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	// Initializing Transport
 	var call transports.CallCloser
 	switch u.Scheme {
 	case "wss", "ws":
-		call, err = websocket.NewTransport(s)
+		call, err = websocket.NewTransport(s, o.websocketOptions...)
 		if err != nil {
 			return nil, err
 		}
 	case "https", "http":
-		call, err = http.NewTransport(s)
+		call, err = http.NewTransport(s, o.httpOptions...)
 		if err != nil {
 			return nil, err
 		}
 	default:
 		return nil, ErrInitializeTransport
 	}
+	return NewClientWithTransport(call, isTestNet), nil
+}
+
+// NewClientWithTransport creates a new RPC client over the given CallCloser,
+// e.g. a failover.Transport spanning several nodes.
+func NewClientWithTransport(call transports.CallCloser, isTestNet bool) *Client {
 	client := &Client{cc: call}
 
 	client.AsyncProtocol = true
@@ -66,7 +110,7 @@ func NewClient(s string, isTestNet bool) (*Client, error) {
 	} else {
 		client.chainID = config.CHAIN_ID_MAINNET
 	}
-	return client, nil
+	return client
 }
 
 // Close should be used to close the client when no longer needed.
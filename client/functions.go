@@ -1,16 +1,17 @@
 package client
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
-	"fmt"
+	"math"
 	"sort"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/thanhxeon2470/beowulf-go/api"
 	"github.com/thanhxeon2470/beowulf-go/config"
+	"github.com/thanhxeon2470/beowulf-go/hdwallet"
+	"github.com/thanhxeon2470/beowulf-go/nft"
 	"github.com/thanhxeon2470/beowulf-go/transactions"
 	"github.com/thanhxeon2470/beowulf-go/types"
 )
@@ -82,9 +83,14 @@ func (client *Client) GetBalance(account, tokenName string, decimals uint8) (*st
 }
 
 func (client *Client) CommitBlockSidechain(csid, fromName, content, fee string) (*OperResp, error) {
-	validate := ValidateFee(fee, config.MIN_TRANSACTION_FEE)
-	if !validate {
-		return nil, errors.New("Fee is not valid")
+	return client.CommitBlockSidechainContext(context.Background(), csid, fromName, content, fee)
+}
+
+//CommitBlockSidechainContext is CommitBlockSidechain with the node requests bound to ctx.
+func (client *Client) CommitBlockSidechainContext(ctx context.Context, csid, fromName, content, fee string) (*OperResp, error) {
+	feeAsset, err := parseFee(fee, config.MIN_TRANSACTION_FEE)
+	if err != nil {
+		return nil, err
 	}
 
 	var trx []types.Operation
@@ -92,11 +98,11 @@ func (client *Client) CommitBlockSidechain(csid, fromName, content, fee string)
 		Committer:   fromName,
 		Csid:        csid,
 		CsOperation: content,
-		Fee:         fee,
+		Fee:         feeAsset,
 	}
 
 	trx = append(trx, tx)
-	resp, err := client.SendTrx(trx, "")
+	resp, err := client.SendTrxContext(ctx, trx, "")
 	return &OperResp{NameOper: "CheckSidechain", Bresp: resp}, err
 }
 
@@ -116,6 +122,11 @@ func (client *Client) GetNFTBalanceOfAccount(account string, limit, offset uint3
 	return client.API.GetNFTBalanceOfAccount(account, limit, offset)
 }
 
+//GetNFTHoldings returns the NFT instances held by the account grouped by symbol
+func (client *Client) GetNFTHoldings(account string, opts ...api.HoldingsOption) ([]api.NFTHoldings, error) {
+	return client.API.GetNFTHoldings(account, opts...)
+}
+
 func (client *Client) GetLatestNFTBlock() (*api.NFTBlock, error) {
 	return client.API.GetLatestNFTBlock()
 }
@@ -128,626 +139,313 @@ func (client *Client) GetNFTTransaction(trxid string) (*api.NFTTransaction, erro
 	return client.API.GetNFTTransaction(trxid)
 }
 
-//Create NFT
-func (client *Client) CreateNFT(fromName, scid, name, symbol, maxSupply, fee string, authorizedIssuingAccounts []string) (*OperResp, error) {
-	validate := ValidateFee(fee, config.MIN_TRANSACTION_FEE)
-	if validate == false {
-		return nil, errors.New("Fee is not valid")
-	}
-	var owners []string
-	owners = append(owners, fromName)
-	if len(name) <= 0 {
-		return nil, errors.New("Name is not valid")
-	}
-	if len(symbol) <= 0 {
-		return nil, errors.New("Symbol is not valid")
-	}
-	if len(scid) <= 0 {
-		scid = "s01"
-	}
-	accounts := ""
-	for i, item := range authorizedIssuingAccounts {
-		if i == 0 {
-			accounts += "[\"" + item + "\""
-			if i == len(authorizedIssuingAccounts)-1 {
-				accounts += "]"
-			}
-		} else if i == len(authorizedIssuingAccounts)-1 {
-			accounts += "," + "\"" + item + "\"]"
-		} else {
-			accounts += "," + "\"" + item + "\""
-		}
-	}
+//Scid returns the sidechain used by the contract queries and by the nft operations without scid
+func (client *Client) Scid() string {
+	return client.API.Scid()
+}
 
-	var scoperation string
-	if len(authorizedIssuingAccounts) > 0 {
-		if len(maxSupply) > 0 {
-			scoperation = fmt.Sprintf("{\"contractName\":\"nft\",\"contractAction\":\"create\",\"contractPayload\":{\"name\":\"%s\",\"symbol\":\"%s\",\"maxSupply\":\"%s\",\"authorizedIssuingAccounts\":%s}}", name, symbol, maxSupply, accounts)
-		} else {
-			scoperation = fmt.Sprintf("{\"contractName\":\"nft\",\"contractAction\":\"create\",\"contractPayload\":{\"name\":\"%s\",\"symbol\":\"%s\",\"authorizedIssuingAccounts\":%s}}", name, symbol, accounts)
-		}
-	} else {
-		if len(maxSupply) > 0 {
-			scoperation = fmt.Sprintf("{\"contractName\":\"nft\",\"contractAction\":\"create\",\"contractPayload\":{\"name\":\"%s\",\"symbol\":\"%s\",\"maxSupply\":\"%s\"}}", name, symbol, maxSupply)
-		} else {
-			scoperation = fmt.Sprintf("{\"contractName\":\"nft\",\"contractAction\":\"create\",\"contractPayload\":{\"name\":\"%s\",\"symbol\":\"%s\"}}", name, symbol)
-		}
-	}
-	//scoperation = fmt.Sprintf("{\"contractName\":\"nft\",\"contractAction\":\"create\",\"contractPayload\":{\"name\":\"%s\",\"symbol\":\"%s\",\"maxSupply\":\"%s\"}}", name, symbol, maxSupply)
-	var trx []types.Operation
-	tx := &types.SmartContractOperation{
-		RequiredOwners: owners,
-		Scid:           scid,
-		ScOperation:    scoperation,
-		Fee:            fee,
-	}
-	trx = append(trx, tx)
-	resp, err := client.SendTrx(trx, "")
-	return &OperResp{NameOper: "SmartContract", Bresp: resp}, err
+//SetScid sets the sidechain used by the contract queries and by the nft operations without scid
+func (client *Client) SetScid(scid string) {
+	client.API.SetScid(scid)
 }
 
-//func (client *Client) UpdateUrl(fromName, scid, symbol, url, fee string) (*OperResp, error) {
-//	validate := ValidateFee(fee, config.MIN_TRANSACTION_FEE)
-//	if validate == false {
-//		return nil, errors.New("Fee is not valid")
-//	}
-//	var owners []string
-//	owners = append(owners, fromName)
-//
-//	var scoperation string
-//	scoperation = fmt.Sprintf("{\"contractName\":\"nft\",\"contractAction\":\"updateMetadata\",\"contractPayload\":{\"symbol\":\"%s\",\"metadata\":{\"url\":\"%s\"}}}", symbol, url)
-//	var trx []types.Operation
-//	tx := &types.SmartContractOperation{
-//		RequiredOwners: owners,
-//		Scid:           scid,
-//		ScOperation:    scoperation,
-//		Fee:            fee,
-//	}
-//	trx = append(trx, tx)
-//	resp, err := client.SendTrx(trx, "")
-//	return &OperResp{NameOper: "SmartContract", Bresp: resp}, err
-//}
-//
-//func (client *Client) UpdateImage(fromName, scid, symbol, image, fee string) (*OperResp, error) {
-//	validate := ValidateFee(fee, config.MIN_TRANSACTION_FEE)
-//	if validate == false {
-//		return nil, errors.New("Fee is not valid")
-//	}
-//	var owners []string
-//	owners = append(owners, fromName)
-//
-//	var scoperation string
-//	scoperation = fmt.Sprintf("{\"contractName\":\"nft\",\"contractAction\":\"updateMetadata\",\"contractPayload\":{\"symbol\":\"%s\",\"metadata\":{\"image\":\"%s\"}}}", symbol, image)
-//	var trx []types.Operation
-//	tx := &types.SmartContractOperation{
-//		RequiredOwners: owners,
-//		Scid:           scid,
-//		ScOperation:    scoperation,
-//		Fee:            fee,
-//	}
-//	trx = append(trx, tx)
-//	resp, err := client.SendTrx(trx, "")
-//	return &OperResp{NameOper: "SmartContract", Bresp: resp}, err
-//}
+//SendNFT sends the actions of the nft contract signed by fromName in one transaction.
+func (client *Client) SendNFT(fromName, scid, fee string, payloads ...nft.Payload) (*OperResp, error) {
+	return client.SendNFTContext(context.Background(), fromName, scid, fee, payloads...)
+}
 
-func (client *Client) UpdateMetadata(fromName, scid, symbol, url, image, fee string) (*OperResp, error) {
-	validate := ValidateFee(fee, config.MIN_TRANSACTION_FEE)
-	if validate == false {
-		return nil, errors.New("Fee is not valid")
+//SendNFTContext is SendNFT with the node requests bound to ctx.
+func (client *Client) SendNFTContext(ctx context.Context, fromName, scid, fee string, payloads ...nft.Payload) (*OperResp, error) {
+	feeAsset, err := parseFee(fee, config.MIN_TRANSACTION_FEE)
+	if err != nil {
+		return nil, err
 	}
-	var owners []string
-	owners = append(owners, fromName)
-	if len(symbol) <= 0 {
-		return nil, errors.New("Symbol is not valid")
+	if len(payloads) == 0 {
+		return nil, errors.New("There is no action to send")
 	}
-	if len(scid) <= 0 {
-		scid = "s01"
+	if len(scid) == 0 {
+		scid = client.Scid()
 	}
-	var scoperation string
-	scoperation = fmt.Sprintf("{\"contractName\":\"nft\",\"contractAction\":\"updateMetadata\",\"contractPayload\":{\"symbol\":\"%s\",\"metadata\":{\"url\":\"%s\",\"image\":\"%s\"}}}", symbol, url, image)
 	var trx []types.Operation
-	tx := &types.SmartContractOperation{
-		RequiredOwners: owners,
-		Scid:           scid,
-		ScOperation:    scoperation,
-		Fee:            fee,
+	for _, payload := range payloads {
+		tx, err := nft.NewOperation(scid, feeAsset, payload, fromName)
+		if err != nil {
+			return nil, err
+		}
+		trx = append(trx, tx)
 	}
-	trx = append(trx, tx)
-	resp, err := client.SendTrx(trx, "")
+	resp, err := client.SendTrxContext(ctx, trx, "")
 	return &OperResp{NameOper: "SmartContract", Bresp: resp}, err
 }
 
+//Create NFT
+func (client *Client) CreateNFT(fromName, scid, name, symbol, maxSupply, fee string, authorizedIssuingAccounts []string) (*OperResp, error) {
+	return client.CreateNFTContext(context.Background(), fromName, scid, name, symbol, maxSupply, fee, authorizedIssuingAccounts)
+}
+
+//CreateNFTContext is CreateNFT with the node requests bound to ctx.
+func (client *Client) CreateNFTContext(ctx context.Context, fromName, scid, name, symbol, maxSupply, fee string, authorizedIssuingAccounts []string) (*OperResp, error) {
+	return client.SendNFTContext(ctx, fromName, scid, fee, &nft.Create{
+		Name:                      name,
+		Symbol:                    symbol,
+		MaxSupply:                 maxSupply,
+		AuthorizedIssuingAccounts: authorizedIssuingAccounts,
+	})
+}
+
+func (client *Client) UpdateMetadata(fromName, scid, symbol, url, image, fee string) (*OperResp, error) {
+	return client.UpdateMetadataContext(context.Background(), fromName, scid, symbol, url, image, fee)
+}
+
+//UpdateMetadataContext is UpdateMetadata with the node requests bound to ctx.
+func (client *Client) UpdateMetadataContext(ctx context.Context, fromName, scid, symbol, url, image, fee string) (*OperResp, error) {
+	return client.SendNFTContext(ctx, fromName, scid, fee, &nft.UpdateMetadata{
+		Symbol:   symbol,
+		Metadata: nft.Metadata{URL: url, Image: image},
+	})
+}
+
 func (client *Client) UpdateName(fromName, scid, symbol, name, fee string) (*OperResp, error) {
-	validate := ValidateFee(fee, config.MIN_TRANSACTION_FEE)
-	if validate == false {
-		return nil, errors.New("Fee is not valid")
-	}
-	var owners []string
-	owners = append(owners, fromName)
-	if len(symbol) <= 0 {
-		return nil, errors.New("Symbol is not valid")
-	}
-	if len(scid) <= 0 {
-		scid = "s01"
-	}
-	var scoperation string
-	scoperation = fmt.Sprintf("{\"contractName\":\"nft\",\"contractAction\":\"updateName\",\"contractPayload\":{\"symbol\":\"%s\",\"name\":\"%s\"}}", symbol, name)
-	var trx []types.Operation
-	tx := &types.SmartContractOperation{
-		RequiredOwners: owners,
-		Scid:           scid,
-		ScOperation:    scoperation,
-		Fee:            fee,
-	}
-	trx = append(trx, tx)
-	resp, err := client.SendTrx(trx, "")
-	return &OperResp{NameOper: "SmartContract", Bresp: resp}, err
+	return client.UpdateNameContext(context.Background(), fromName, scid, symbol, name, fee)
+}
+
+//UpdateNameContext is UpdateName with the node requests bound to ctx.
+func (client *Client) UpdateNameContext(ctx context.Context, fromName, scid, symbol, name, fee string) (*OperResp, error) {
+	return client.SendNFTContext(ctx, fromName, scid, fee, &nft.UpdateName{Symbol: symbol, Name: name})
 }
 
 func (client *Client) UpdateOrgName(fromName, scid, symbol, orgName, fee string) (*OperResp, error) {
-	validate := ValidateFee(fee, config.MIN_TRANSACTION_FEE)
-	if validate == false {
-		return nil, errors.New("Fee is not valid")
-	}
-	var owners []string
-	owners = append(owners, fromName)
-	if len(symbol) <= 0 {
-		return nil, errors.New("Symbol is not valid")
-	}
-	if len(scid) <= 0 {
-		scid = "s01"
-	}
-	var scoperation string
-	scoperation = fmt.Sprintf("{\"contractName\":\"nft\",\"contractAction\":\"updateOrgName\",\"contractPayload\":{\"symbol\":\"%s\",\"orgName\":\"%s\"}}", symbol, orgName)
-	var trx []types.Operation
-	tx := &types.SmartContractOperation{
-		RequiredOwners: owners,
-		Scid:           scid,
-		ScOperation:    scoperation,
-		Fee:            fee,
-	}
-	trx = append(trx, tx)
-	resp, err := client.SendTrx(trx, "")
-	return &OperResp{NameOper: "SmartContract", Bresp: resp}, err
+	return client.UpdateOrgNameContext(context.Background(), fromName, scid, symbol, orgName, fee)
+}
+
+//UpdateOrgNameContext is UpdateOrgName with the node requests bound to ctx.
+func (client *Client) UpdateOrgNameContext(ctx context.Context, fromName, scid, symbol, orgName, fee string) (*OperResp, error) {
+	return client.SendNFTContext(ctx, fromName, scid, fee, &nft.UpdateOrgName{Symbol: symbol, OrgName: orgName})
 }
 
 func (client *Client) AddProperty(fromName, scid, symbol, propertyName, propertyType, fee string, authorizedEditingAccounts []string) (*OperResp, error) {
-	validate := ValidateFee(fee, config.MIN_TRANSACTION_FEE)
-	if validate == false {
-		return nil, errors.New("Fee is not valid")
-	}
-	var owners []string
-	owners = append(owners, fromName)
-	if len(propertyName) <= 0 || len(propertyType) <= 0 {
-		return nil, errors.New("Property is not valid")
-	}
-	if len(symbol) <= 0 {
-		return nil, errors.New("Symbol is not valid")
-	}
-	if len(scid) <= 0 {
-		scid = "s01"
-	}
-	accounts := ""
-	for i, item := range authorizedEditingAccounts {
-		if i == 0 {
-			accounts += "[\"" + item + "\""
-			if i == len(authorizedEditingAccounts)-1 {
-				accounts += "]"
-			}
-		} else if i == len(authorizedEditingAccounts)-1 {
-			accounts += "," + "\"" + item + "\"]"
-		} else {
-			accounts += "," + "\"" + item + "\""
-		}
-	}
-	var scoperation string
-	if len(authorizedEditingAccounts) > 0 {
-		scoperation = fmt.Sprintf("{\"contractName\":\"nft\",\"contractAction\":\"addProperty\",\"contractPayload\":{\"symbol\":\"%s\",\"name\":\"%s\",\"type\":\"%s\",\"authorizedEditingAccounts\":%s}}", symbol, propertyName, propertyType, accounts)
-	} else {
-		scoperation = fmt.Sprintf("{\"contractName\":\"nft\",\"contractAction\":\"addProperty\",\"contractPayload\":{\"symbol\":\"%s\",\"name\":\"%s\",\"type\":\"%s\"}}", symbol, propertyName, propertyType)
-	}
-	var trx []types.Operation
-	tx := &types.SmartContractOperation{
-		RequiredOwners: owners,
-		Scid:           scid,
-		ScOperation:    scoperation,
-		Fee:            fee,
-	}
-	trx = append(trx, tx)
-	resp, err := client.SendTrx(trx, "")
-	return &OperResp{NameOper: "SmartContract", Bresp: resp}, err
+	return client.AddPropertyContext(context.Background(), fromName, scid, symbol, propertyName, propertyType, fee, authorizedEditingAccounts)
+}
+
+//AddPropertyContext is AddProperty with the node requests bound to ctx.
+func (client *Client) AddPropertyContext(ctx context.Context, fromName, scid, symbol, propertyName, propertyType, fee string, authorizedEditingAccounts []string) (*OperResp, error) {
+	return client.SendNFTContext(ctx, fromName, scid, fee, &nft.AddProperty{
+		Symbol:                    symbol,
+		Name:                      propertyName,
+		Type:                      nft.PropertyType(propertyType),
+		AuthorizedEditingAccounts: authorizedEditingAccounts,
+	})
 }
 
 func (client *Client) IssueNFT(fromName, scid, symbol, to, fee string) (*OperResp, error) {
-	validate := ValidateFee(fee, config.MIN_TRANSACTION_FEE)
-	if validate == false {
-		return nil, errors.New("Fee is not valid")
-	}
-	var owners []string
-	owners = append(owners, fromName)
-	if len(to) <= 0 {
-		return nil, errors.New("Recipient is not valid")
-	}
-	if len(symbol) <= 0 {
-		return nil, errors.New("Symbol is not valid")
-	}
-	if len(scid) <= 0 {
-		scid = "s01"
-	}
+	return client.IssueNFTContext(context.Background(), fromName, scid, symbol, to, fee)
+}
 
-	var scoperation string
-	scoperation = fmt.Sprintf("{\"contractName\":\"nft\",\"contractAction\":\"issue\",\"contractPayload\":{\"symbol\":\"%s\",\"to\":\"%s\",\"toType\":\"user\",\"feeSymbol\":\"BEE\"}}", symbol, to)
-	var trx []types.Operation
-	tx := &types.SmartContractOperation{
-		RequiredOwners: owners,
-		Scid:           scid,
-		ScOperation:    scoperation,
-		Fee:            fee,
-	}
-	trx = append(trx, tx)
-	resp, err := client.SendTrx(trx, "")
-	return &OperResp{NameOper: "SmartContract", Bresp: resp}, err
+//IssueNFTContext is IssueNFT with the node requests bound to ctx.
+func (client *Client) IssueNFTContext(ctx context.Context, fromName, scid, symbol, to, fee string) (*OperResp, error) {
+	return client.SendNFTContext(ctx, fromName, scid, fee, nft.NewIssue(symbol, to))
 }
 
 func (client *Client) IssueWithProperties(fromName, scid, symbol, to, fee string, properties interface{}) (*OperResp, error) {
-	validate := ValidateFee(fee, config.MIN_TRANSACTION_FEE)
-	if validate == false {
-		return nil, errors.New("Fee is not valid")
-	}
-	var owners []string
-	owners = append(owners, fromName)
-	if len(to) <= 0 {
-		return nil, errors.New("Recipient is not valid")
-	}
-	if len(symbol) <= 0 {
-		return nil, errors.New("Symbol is not valid")
-	}
-	if len(scid) <= 0 {
-		scid = "s01"
-	}
-	b, err := json.Marshal(properties)
-	if err != nil {
-		fmt.Printf("Error: %s", err)
-		return nil, err
-	}
-	var scoperation string
-	scoperation = fmt.Sprintf("{\"contractName\":\"nft\",\"contractAction\":\"issue\",\"contractPayload\":{\"symbol\":\"%s\",\"to\":\"%s\",\"toType\":\"user\",\"feeSymbol\":\"BEE\",\"properties\":%v}}", symbol, to, string(b))
-	var trx []types.Operation
-	tx := &types.SmartContractOperation{
-		RequiredOwners: owners,
-		Scid:           scid,
-		ScOperation:    scoperation,
-		Fee:            fee,
-	}
-	trx = append(trx, tx)
-	resp, err := client.SendTrx(trx, "")
-	return &OperResp{NameOper: "SmartContract", Bresp: resp}, err
+	return client.IssueWithPropertiesContext(context.Background(), fromName, scid, symbol, to, fee, properties)
+}
+
+//IssueWithPropertiesContext is IssueWithProperties with the node requests bound to ctx.
+func (client *Client) IssueWithPropertiesContext(ctx context.Context, fromName, scid, symbol, to, fee string, properties interface{}) (*OperResp, error) {
+	issue := nft.NewIssue(symbol, to)
+	issue.Properties = properties
+	return client.SendNFTContext(ctx, fromName, scid, fee, issue)
 }
 
 func (client *Client) TransferNFT(fromName, scid, to, fee string, nfts []api.NFTTransferRequest) (*OperResp, error) {
-	validate := ValidateFee(fee, config.MIN_TRANSACTION_FEE)
-	if validate == false {
-		return nil, errors.New("Fee is not valid")
-	}
-	if len(nfts) <= 0 {
-		return nil, errors.New("There is no nft to transfer")
-	}
-	if len(to) <= 0 {
-		return nil, errors.New("Recipient is not valid")
-	}
-	if len(scid) <= 0 {
-		scid = "s01"
-	}
-	var owners []string
-	owners = append(owners, fromName)
-	b, err := json.Marshal(nfts)
-	if err != nil {
-		fmt.Printf("Error: %s", err)
-		return nil, err
-	}
-	data := string(b)
-	var scoperation string
-	scoperation = fmt.Sprintf("{\"contractName\":\"nft\",\"contractAction\":\"transfer\",\"contractPayload\":{\"to\":\"%s\",\"nfts\":%v}}", to, data)
-	var trx []types.Operation
-	tx := &types.SmartContractOperation{
-		RequiredOwners: owners,
-		Scid:           scid,
-		ScOperation:    scoperation,
-		Fee:            fee,
-	}
-	trx = append(trx, tx)
-	resp, err := client.SendTrx(trx, "")
-	return &OperResp{NameOper: "SmartContract", Bresp: resp}, err
+	return client.TransferNFTContext(context.Background(), fromName, scid, to, fee, nfts)
+}
+
+//TransferNFTContext is TransferNFT with the node requests bound to ctx.
+func (client *Client) TransferNFTContext(ctx context.Context, fromName, scid, to, fee string, nfts []api.NFTTransferRequest) (*OperResp, error) {
+	return client.SendNFTContext(ctx, fromName, scid, fee, &nft.Transfer{To: to, Nfts: instanceIDs(nfts)})
 }
 
 func (client *Client) AddAuthorizedIssuingAccounts(fromName, scid, symbol, fee string, issuingAccounts []string) (*OperResp, error) {
-	validate := ValidateFee(fee, config.MIN_TRANSACTION_FEE)
-	if validate == false {
-		return nil, errors.New("Fee is not valid")
-	}
-	if len(issuingAccounts) <= 0 {
-		return nil, errors.New("There is no account to add")
-	}
-	if len(symbol) <= 0 {
-		return nil, errors.New("Symbol is not valid")
-	}
-	if len(scid) <= 0 {
-		scid = "s01"
-	}
-	var owners []string
-	owners = append(owners, fromName)
-	accounts := ""
-	for i, item := range issuingAccounts {
-		if i == 0 {
-			accounts += "[\"" + item + "\""
-			if i == len(issuingAccounts)-1 {
-				accounts += "]"
-			}
-		} else if i == len(issuingAccounts)-1 {
-			accounts += "," + "\"" + item + "\"]"
-		} else {
-			accounts += "," + "\"" + item + "\""
-		}
-	}
-	var scoperation string
-	scoperation = fmt.Sprintf("{\"contractName\":\"nft\",\"contractAction\":\"addAuthorizedIssuingAccounts\",\"contractPayload\":{\"symbol\":\"%s\",\"accounts\":%s}}", symbol, accounts)
-	var trx []types.Operation
-	tx := &types.SmartContractOperation{
-		RequiredOwners: owners,
-		Scid:           scid,
-		ScOperation:    scoperation,
-		Fee:            fee,
-	}
-	trx = append(trx, tx)
-	resp, err := client.SendTrx(trx, "")
-	return &OperResp{NameOper: "SmartContract", Bresp: resp}, err
+	return client.AddAuthorizedIssuingAccountsContext(context.Background(), fromName, scid, symbol, fee, issuingAccounts)
+}
+
+//AddAuthorizedIssuingAccountsContext is AddAuthorizedIssuingAccounts with the node requests bound to ctx.
+func (client *Client) AddAuthorizedIssuingAccountsContext(ctx context.Context, fromName, scid, symbol, fee string, issuingAccounts []string) (*OperResp, error) {
+	return client.SendNFTContext(ctx, fromName, scid, fee, &nft.AddAuthorizedIssuingAccounts{Symbol: symbol, Accounts: issuingAccounts})
 }
 
 func (client *Client) RemoveAuthorizedIssuingAccounts(fromName, scid, symbol, fee string, issuingAccounts []string) (*OperResp, error) {
-	validate := ValidateFee(fee, config.MIN_TRANSACTION_FEE)
-	if validate == false {
-		return nil, errors.New("Fee is not valid")
-	}
-	if len(issuingAccounts) <= 0 {
-		return nil, errors.New("There is no account to remove")
-	}
-	if len(symbol) <= 0 {
-		return nil, errors.New("Symbol is not valid")
-	}
-	if len(scid) <= 0 {
-		scid = "s01"
-	}
-	var owners []string
-	owners = append(owners, fromName)
-	accounts := ""
-	for i, item := range issuingAccounts {
-		if i == 0 {
-			accounts += "[\"" + item + "\""
-			if i == len(issuingAccounts)-1 {
-				accounts += "]"
-			}
-		} else if i == len(issuingAccounts)-1 {
-			accounts += "," + "\"" + item + "\"]"
-		} else {
-			accounts += "," + "\"" + item + "\""
-		}
-	}
-	var scoperation string
-	scoperation = fmt.Sprintf("{\"contractName\":\"nft\",\"contractAction\":\"removeAuthorizedIssuingAccounts\",\"contractPayload\":{\"symbol\":\"%s\",\"accounts\":%s}}", symbol, accounts)
-	var trx []types.Operation
-	tx := &types.SmartContractOperation{
-		RequiredOwners: owners,
-		Scid:           scid,
-		ScOperation:    scoperation,
-		Fee:            fee,
-	}
-	trx = append(trx, tx)
-	resp, err := client.SendTrx(trx, "")
-	return &OperResp{NameOper: "SmartContract", Bresp: resp}, err
+	return client.RemoveAuthorizedIssuingAccountsContext(context.Background(), fromName, scid, symbol, fee, issuingAccounts)
+}
+
+//RemoveAuthorizedIssuingAccountsContext is RemoveAuthorizedIssuingAccounts with the node requests bound to ctx.
+func (client *Client) RemoveAuthorizedIssuingAccountsContext(ctx context.Context, fromName, scid, symbol, fee string, issuingAccounts []string) (*OperResp, error) {
+	return client.SendNFTContext(ctx, fromName, scid, fee, &nft.RemoveAuthorizedIssuingAccounts{Symbol: symbol, Accounts: issuingAccounts})
 }
 
 func (client *Client) UpdatePropertyDefinition(fromName, scid, symbol, propertyName, newPropertyName, newPropertyType, fee string) (*OperResp, error) {
-	validate := ValidateFee(fee, config.MIN_TRANSACTION_FEE)
-	if validate == false {
-		return nil, errors.New("Fee is not valid")
-	}
-	if len(propertyName) <= 0 || len(newPropertyName) <= 0 || len(newPropertyType) <= 0 {
-		return nil, errors.New("Property is not valid")
-	}
-	if len(symbol) <= 0 {
-		return nil, errors.New("Symbol is not valid")
-	}
-	if len(scid) <= 0 {
-		scid = "s01"
-	}
-	var owners []string
-	owners = append(owners, fromName)
+	return client.UpdatePropertyDefinitionContext(context.Background(), fromName, scid, symbol, propertyName, newPropertyName, newPropertyType, fee)
+}
 
-	var scoperation string
-	scoperation = fmt.Sprintf("{\"contractName\":\"nft\",\"contractAction\":\"updatePropertyDefinition\",\"contractPayload\":{\"symbol\":\"%s\",\"name\":\"%s\",\"type\":\"%s\",\"newName\":\"%s\"}}", symbol, propertyName, newPropertyType, newPropertyName)
-	var trx []types.Operation
-	tx := &types.SmartContractOperation{
-		RequiredOwners: owners,
-		Scid:           scid,
-		ScOperation:    scoperation,
-		Fee:            fee,
-	}
-	trx = append(trx, tx)
-	resp, err := client.SendTrx(trx, "")
-	return &OperResp{NameOper: "SmartContract", Bresp: resp}, err
+//UpdatePropertyDefinitionContext is UpdatePropertyDefinition with the node requests bound to ctx.
+func (client *Client) UpdatePropertyDefinitionContext(ctx context.Context, fromName, scid, symbol, propertyName, newPropertyName, newPropertyType, fee string) (*OperResp, error) {
+	return client.SendNFTContext(ctx, fromName, scid, fee, &nft.UpdatePropertyDefinition{
+		Symbol:  symbol,
+		Name:    propertyName,
+		Type:    nft.PropertyType(newPropertyType),
+		NewName: newPropertyName,
+	})
 }
 
 func (client *Client) SetProperties(fromName, scid, symbol, fee string, nfts []api.NFTProperty) (*OperResp, error) {
-	validate := ValidateFee(fee, config.MIN_TRANSACTION_FEE)
-	if validate == false {
-		return nil, errors.New("Fee is not valid")
-	}
-	if len(nfts) <= 0 {
-		return nil, errors.New("There is no property to set")
-	}
-	if len(symbol) <= 0 {
-		return nil, errors.New("Recipient is not valid")
-	}
-	if len(scid) <= 0 {
-		scid = "s01"
-	}
-	var owners []string
-	owners = append(owners, fromName)
-	b, err := json.Marshal(nfts)
-	if err != nil {
-		fmt.Printf("Error: %s", err)
-		return nil, err
-	}
-	var scoperation string
-	scoperation = fmt.Sprintf("{\"contractName\":\"nft\",\"contractAction\":\"setProperties\",\"contractPayload\":{\"symbol\":\"%s\",\"nfts\":%v}}", symbol, string(b))
-	var trx []types.Operation
-	tx := &types.SmartContractOperation{
-		RequiredOwners: owners,
-		Scid:           scid,
-		ScOperation:    scoperation,
-		Fee:            fee,
+	return client.SetPropertiesContext(context.Background(), fromName, scid, symbol, fee, nfts)
+}
+
+//SetPropertiesContext is SetProperties with the node requests bound to ctx.
+func (client *Client) SetPropertiesContext(ctx context.Context, fromName, scid, symbol, fee string, nfts []api.NFTProperty) (*OperResp, error) {
+	payload := &nft.SetProperties{Symbol: symbol}
+	for _, item := range nfts {
+		payload.Nfts = append(payload.Nfts, nft.InstanceProperties{
+			Properties: nft.PropertyValue{Name: item.Properties.Name, Data: item.Properties.Data},
+			Id:         item.Id,
+		})
 	}
-	trx = append(trx, tx)
-	resp, err := client.SendTrx(trx, "")
-	return &OperResp{NameOper: "SmartContract", Bresp: resp}, err
+	return client.SendNFTContext(ctx, fromName, scid, fee, payload)
 }
 
 func (client *Client) BurnNFT(fromName, scid, fee string, nfts []api.NFTTransferRequest) (*OperResp, error) {
-	validate := ValidateFee(fee, config.MIN_TRANSACTION_FEE)
-	if validate == false {
-		return nil, errors.New("Fee is not valid")
-	}
-	if len(nfts) <= 0 {
-		return nil, errors.New("There is no nft to burn")
-	}
-	if len(scid) <= 0 {
-		scid = "s01"
-	}
-	var owners []string
-	owners = append(owners, fromName)
-	b, err := json.Marshal(nfts)
-	if err != nil {
-		fmt.Printf("Error: %s", err)
-		return nil, err
-	}
-	var scoperation string
-	scoperation = fmt.Sprintf("{\"contractName\":\"nft\",\"contractAction\":\"burn\",\"contractPayload\":{\"nfts\":%v}}", string(b))
-	var trx []types.Operation
-	tx := &types.SmartContractOperation{
-		RequiredOwners: owners,
-		Scid:           scid,
-		ScOperation:    scoperation,
-		Fee:            fee,
-	}
-	trx = append(trx, tx)
-	resp, err := client.SendTrx(trx, "")
-	return &OperResp{NameOper: "SmartContract", Bresp: resp}, err
+	return client.BurnNFTContext(context.Background(), fromName, scid, fee, nfts)
+}
+
+//BurnNFTContext is BurnNFT with the node requests bound to ctx.
+func (client *Client) BurnNFTContext(ctx context.Context, fromName, scid, fee string, nfts []api.NFTTransferRequest) (*OperResp, error) {
+	return client.SendNFTContext(ctx, fromName, scid, fee, &nft.Burn{Nfts: instanceIDs(nfts)})
 }
 
 func (client *Client) MultipleIssueNFT(fromName, scid, fee string, instances []api.Instance) (*OperResp, error) {
-	validate := ValidateFee(fee, config.MIN_TRANSACTION_FEE)
-	if validate == false {
-		return nil, errors.New("Fee is not valid")
-	}
-	if len(instances) <= 0 {
-		return nil, errors.New("There is no nft to issue")
-	}
-	if len(scid) <= 0 {
-		scid = "s01"
-	}
-	var owners []string
-	owners = append(owners, fromName)
-	b, err := json.Marshal(instances)
-	if err != nil {
-		fmt.Printf("Error: %s", err)
-		return nil, err
-	}
-	var scoperation string
-	scoperation = fmt.Sprintf("{\"contractName\":\"nft\",\"contractAction\":\"issueMultiple\",\"contractPayload\":{\"instances\":%v}}", string(b))
-	var trx []types.Operation
-	tx := &types.SmartContractOperation{
-		RequiredOwners: owners,
-		Scid:           scid,
-		ScOperation:    scoperation,
-		Fee:            fee,
+	return client.MultipleIssueNFTContext(context.Background(), fromName, scid, fee, instances)
+}
+
+//MultipleIssueNFTContext is MultipleIssueNFT with the node requests bound to ctx.
+func (client *Client) MultipleIssueNFTContext(ctx context.Context, fromName, scid, fee string, instances []api.Instance) (*OperResp, error) {
+	payload := &nft.IssueMultiple{}
+	for _, item := range instances {
+		payload.Instances = append(payload.Instances, nft.Issue{
+			Symbol:    item.Symbol,
+			To:        item.To,
+			ToType:    item.ToType,
+			FeeSymbol: item.FeeSymbol,
+		})
+	}
+	return client.SendNFTContext(ctx, fromName, scid, fee, payload)
+}
+
+func instanceIDs(nfts []api.NFTTransferRequest) []nft.InstanceIDs {
+	ids := make([]nft.InstanceIDs, 0, len(nfts))
+	for _, item := range nfts {
+		ids = append(ids, nft.InstanceIDs{Symbol: item.Symbol, Ids: item.Ids})
 	}
-	trx = append(trx, tx)
-	resp, err := client.SendTrx(trx, "")
-	return &OperResp{NameOper: "SmartContract", Bresp: resp}, err
+	return ids
 }
 
 //Transfer of funds to any user.
 func (client *Client) Transfer(fromName, toName, memo, amount, fee string) (*OperResp, error) {
-	validate := ValidateFee(fee, config.MIN_TRANSACTION_FEE)
-	if validate == false {
-		return nil, errors.New("Fee is not valid")
+	return client.TransferContext(context.Background(), fromName, toName, memo, amount, fee)
+}
+
+//TransferContext is Transfer with the node requests bound to ctx.
+func (client *Client) TransferContext(ctx context.Context, fromName, toName, memo, amount, fee string) (*OperResp, error) {
+	feeAsset, err := parseFee(fee, config.MIN_TRANSACTION_FEE)
+	if err != nil {
+		return nil, err
 	}
-	validate = ValidateAmount(amount)
-	if validate == false {
-		return nil, errors.New("Amount is not valid")
+	amountAsset, err := parseAmount(amount)
+	if err != nil {
+		return nil, err
 	}
 	var trx []types.Operation
 	tx := &types.TransferOperation{
 		From:   fromName,
 		To:     toName,
-		Amount: amount,
-		Fee:    fee,
+		Amount: amountAsset,
+		Fee:    feeAsset,
 		Memo:   memo,
 	}
 	trx = append(trx, tx)
-	resp, err := client.SendTrx(trx, "")
+	resp, err := client.SendTrxContext(ctx, trx, "")
 	return &OperResp{NameOper: "Transfer", Bresp: resp}, err
 }
 
 func (client *Client) TransferEx(fromName, toName, memo, amount, fee string, extension string) (*OperResp, error) {
-	validate := ValidateFee(fee, config.MIN_TRANSACTION_FEE)
-	if validate == false {
-		return nil, errors.New("Fee is not valid")
+	return client.TransferExContext(context.Background(), fromName, toName, memo, amount, fee, extension)
+}
+
+//TransferExContext is TransferEx with the node requests bound to ctx.
+func (client *Client) TransferExContext(ctx context.Context, fromName, toName, memo, amount, fee string, extension string) (*OperResp, error) {
+	feeAsset, err := parseFee(fee, config.MIN_TRANSACTION_FEE)
+	if err != nil {
+		return nil, err
 	}
-	validate = ValidateAmount(amount)
-	if validate == false {
-		return nil, errors.New("Amount is not valid")
+	amountAsset, err := parseAmount(amount)
+	if err != nil {
+		return nil, err
 	}
 	var trx []types.Operation
 	tx := &types.TransferOperation{
 		From:   fromName,
 		To:     toName,
-		Amount: amount,
-		Fee:    fee,
+		Amount: amountAsset,
+		Fee:    feeAsset,
 		Memo:   memo,
 	}
 	trx = append(trx, tx)
-	resp, err := client.SendTrx(trx, extension)
+	resp, err := client.SendTrxContext(ctx, trx, extension)
 	return &OperResp{NameOper: "Transfer", Bresp: resp}, err
 }
 
 func (client *Client) MultiOp(trx []types.Operation, extension string) (*OperResp, error) {
-	resp, err := client.SendTrx(trx, extension)
+	return client.MultiOpContext(context.Background(), trx, extension)
+}
+
+//MultiOpContext is MultiOp with the node requests bound to ctx.
+func (client *Client) MultiOpContext(ctx context.Context, trx []types.Operation, extension string) (*OperResp, error) {
+	resp, err := client.SendTrxContext(ctx, trx, extension)
 	return &OperResp{NameOper: "Multi", Bresp: resp}, err
 }
 
 func (client *Client) CreateToken(creator, controlAcc, tokenName string, decimals uint8, maxSupply uint64) (*OperResp, error) {
+	return client.CreateTokenContext(context.Background(), creator, controlAcc, tokenName, decimals, maxSupply)
+}
+
+//CreateTokenContext is CreateToken with the node requests bound to ctx.
+func (client *Client) CreateTokenContext(ctx context.Context, creator, controlAcc, tokenName string, decimals uint8, maxSupply uint64) (*OperResp, error) {
 	var trx []types.Operation
 	tx := &types.SmtCreateOperation{
 		ControlAccount: controlAcc,
 		Symbol:         &types.AssetSymbol{Decimals: decimals, AssetName: tokenName},
 		Creator:        creator,
-		SmtCreationFee: config.SMT_CREATION_FEE,
+		SmtCreationFee: types.MustParseAsset(config.SMT_CREATION_FEE),
 		Precision:      decimals,
 		Extensions:     [][]interface{}{},
 		MaxSupply:      maxSupply,
 	}
 
 	trx = append(trx, tx)
-	resp, err := client.SendTrx(trx, "")
+	resp, err := client.SendTrxContext(ctx, trx, "")
 	return &OperResp{NameOper: "SmtCreate", Bresp: resp}, err
 }
 
 //AccountSupernodeVote of voting for the delegate.
 func (client *Client) AccountSupernodeVote(username, supernodeName, fee string, votes int64) (*OperResp, error) {
-	validate := ValidateFee(fee, config.MIN_TRANSACTION_FEE)
-	if validate == false {
-		return nil, errors.New("Fee is not valid")
+	return client.AccountSupernodeVoteContext(context.Background(), username, supernodeName, fee, votes)
+}
+
+//AccountSupernodeVoteContext is AccountSupernodeVote with the node requests bound to ctx.
+func (client *Client) AccountSupernodeVoteContext(ctx context.Context, username, supernodeName, fee string, votes int64) (*OperResp, error) {
+	feeAsset, err := parseFee(fee, config.MIN_TRANSACTION_FEE)
+	if err != nil {
+		return nil, err
 	}
 	if votes <= 0 {
 		return nil, errors.New("Vote is not valid")
@@ -758,20 +456,24 @@ func (client *Client) AccountSupernodeVote(username, supernodeName, fee string,
 		Supernode: supernodeName,
 		Approve:   true,
 		Votes:     votes,
-		Fee:       fee,
+		Fee:       feeAsset,
 	}
 
 	trx = append(trx, tx)
-	resp, err := client.SendTrx(trx, "")
+	resp, err := client.SendTrxContext(ctx, trx, "")
 	return &OperResp{NameOper: "AccountSupernodeVote", Bresp: resp}, err
 }
 
 //Unvote
 func (client *Client) AccountSupernodeUnvote(username, supernodeName, fee string) (*OperResp, error) {
-	validate := ValidateFee(fee, config.MIN_TRANSACTION_FEE)
+	return client.AccountSupernodeUnvoteContext(context.Background(), username, supernodeName, fee)
+}
 
-	if validate == false {
-		return nil, errors.New("Fee is not valid")
+//AccountSupernodeUnvoteContext is AccountSupernodeUnvote with the node requests bound to ctx.
+func (client *Client) AccountSupernodeUnvoteContext(ctx context.Context, username, supernodeName, fee string) (*OperResp, error) {
+	feeAsset, err := parseFee(fee, config.MIN_TRANSACTION_FEE)
+	if err != nil {
+		return nil, err
 	}
 	var trx []types.Operation
 	tx := &types.AccountSupernodeVoteOperation{
@@ -779,76 +481,89 @@ func (client *Client) AccountSupernodeUnvote(username, supernodeName, fee string
 		Supernode: supernodeName,
 		Approve:   false,
 		Votes:     0,
-		Fee:       fee,
+		Fee:       feeAsset,
 	}
 
 	trx = append(trx, tx)
-	resp, err := client.SendTrx(trx, "")
+	resp, err := client.SendTrxContext(ctx, trx, "")
 	return &OperResp{NameOper: "AccountSupernodeVote", Bresp: resp}, err
 }
 
 //TransferToVesting transfer to POWER
 func (client *Client) TransferToVesting(from, to, amount, fee string) (*OperResp, error) {
-	validate := ValidateFee(fee, config.MIN_TRANSACTION_FEE)
-	if validate == false {
-		return nil, errors.New("Fee is not valid")
+	return client.TransferToVestingContext(context.Background(), from, to, amount, fee)
+}
+
+//TransferToVestingContext is TransferToVesting with the node requests bound to ctx.
+func (client *Client) TransferToVestingContext(ctx context.Context, from, to, amount, fee string) (*OperResp, error) {
+	feeAsset, err := parseFee(fee, config.MIN_TRANSACTION_FEE)
+	if err != nil {
+		return nil, err
 	}
-	validate = ValidateAmount(amount)
-	if validate == false {
-		return nil, errors.New("Amount is not valid")
+	amountAsset, err := parseAmount(amount)
+	if err != nil {
+		return nil, err
 	}
 	var trx []types.Operation
 	tx := &types.TransferToVestingOperation{
 		From:   from,
 		To:     to,
-		Amount: amount,
-		Fee:    fee,
+		Amount: amountAsset,
+		Fee:    feeAsset,
 	}
 
 	trx = append(trx, tx)
-	resp, err := client.SendTrx(trx, "")
+	resp, err := client.SendTrxContext(ctx, trx, "")
 	return &OperResp{NameOper: "TransferToVesting", Bresp: resp}, err
 }
 
 //WithdrawVesting down POWER
 func (client *Client) WithdrawVesting(account, vshares, fee string) (*OperResp, error) {
-	validate := ValidateFee(fee, config.MIN_TRANSACTION_FEE)
+	return client.WithdrawVestingContext(context.Background(), account, vshares, fee)
+}
 
-	if validate == false {
-		return nil, errors.New("Fee is not valid")
+//WithdrawVestingContext is WithdrawVesting with the node requests bound to ctx.
+func (client *Client) WithdrawVestingContext(ctx context.Context, account, vshares, fee string) (*OperResp, error) {
+	feeAsset, err := parseFee(fee, config.MIN_TRANSACTION_FEE)
+	if err != nil {
+		return nil, err
 	}
-	validate = ValidateAmount(vshares)
-	if validate == false {
-		return nil, errors.New("Amount is not valid")
+	vsharesAsset, err := parseAmount(vshares)
+	if err != nil {
+		return nil, err
 	}
 	var trx []types.Operation
 	tx := &types.WithdrawVestingOperation{
 		Account:       account,
-		VestingShares: vshares,
-		Fee:           fee,
+		VestingShares: vsharesAsset,
+		Fee:           feeAsset,
 	}
 
 	trx = append(trx, tx)
-	resp, err := client.SendTrx(trx, "")
+	resp, err := client.SendTrxContext(ctx, trx, "")
 	return &OperResp{NameOper: "WithdrawVesting", Bresp: resp}, err
 }
 
 //SupernodeUpdate updating delegate data
 func (client *Client) SupernodeUpdate(owner, blocksigningkey, fee string) (*OperResp, error) {
-	validate := ValidateFee(fee, config.MIN_TRANSACTION_FEE)
+	return client.SupernodeUpdateContext(context.Background(), owner, blocksigningkey, fee)
+}
 
-	if validate == false {
-		return nil, errors.New("Fee is not valid")
+//SupernodeUpdateContext is SupernodeUpdate with the node requests bound to ctx.
+func (client *Client) SupernodeUpdateContext(ctx context.Context, owner, blocksigningkey, fee string) (*OperResp, error) {
+	feeAsset, err := parseFee(fee, config.MIN_TRANSACTION_FEE)
+	if err != nil {
+		return nil, err
 	}
 	var trx []types.Operation
 	tx := &types.SupernodeUpdateOperation{
 		Owner:           owner,
 		BlockSigningKey: blocksigningkey,
-		Fee:             fee,
+		Fee:             feeAsset,
 	}
 
 	trx = append(trx, tx)
-	resp, err := client.SendTrx(trx, "")
+	resp, err := client.SendTrxContext(ctx, trx, "")
 	return &OperResp{NameOper: "SupernodeUpdate", Bresp: resp}, err
 }
 
@@ -867,14 +582,45 @@ func (client *Client) GenKeys(newAccountName string) (*WalletData, error) {
 	return &WalletData{Name: newAccountName, PrivateKey: priv, PublicKey: pub}, nil
 }
 
+//GenKeysFromSeed derives the keys of a new account at path from the master key, e.g. of
+//hdwallet.NewMasterFromMnemonic, so they can be regenerated from the seed. The PrivateKey
+//is empty when master is a public extended key.
+func (client *Client) GenKeysFromSeed(master *hdwallet.ExtendedKey, path, newAccountName string) (*WalletData, error) {
+	pair, err := master.DeriveKeyPair(path)
+	if err != nil {
+		return nil, err
+	}
+	return &WalletData{Name: newAccountName, PrivateKey: pair.PrivateKey, PublicKey: pair.PublicKey}, nil
+}
+
+//AccountCreateFromSeed creates an account owned by the key derived at path from the master key
+func (client *Client) AccountCreateFromSeed(creator, newAccountName string, master *hdwallet.ExtendedKey, path, fee string) (*OperResp, *WalletData, error) {
+	return client.AccountCreateFromSeedContext(context.Background(), creator, newAccountName, master, path, fee)
+}
+
+//AccountCreateFromSeedContext is AccountCreateFromSeed with the node requests bound to ctx.
+func (client *Client) AccountCreateFromSeedContext(ctx context.Context, creator, newAccountName string, master *hdwallet.ExtendedKey, path, fee string) (*OperResp, *WalletData, error) {
+	walletData, err := client.GenKeysFromSeed(master, path, newAccountName)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := client.AccountCreateContext(ctx, creator, newAccountName, walletData.PublicKey, fee)
+	return resp, walletData, err
+}
+
 func (client *Client) AccountCreate(creator, newAccountName, publicKey, fee string) (*OperResp, error) {
+	return client.AccountCreateContext(context.Background(), creator, newAccountName, publicKey, fee)
+}
+
+//AccountCreateContext is AccountCreate with the node requests bound to ctx.
+func (client *Client) AccountCreateContext(ctx context.Context, creator, newAccountName, publicKey, fee string) (*OperResp, error) {
 	err := ValidateNameAccount(newAccountName)
 	if err != nil {
 		return nil, err
 	}
-	validate := ValidateFee(fee, config.MIN_ACCOUNT_CREATION_FEE)
-	if validate == false {
-		return nil, errors.New("Fee is not valid")
+	feeAsset, err := parseFee(fee, config.MIN_ACCOUNT_CREATION_FEE)
+	if err != nil {
+		return nil, err
 	}
 	var trx []types.Operation
 	empty := map[string]int64{}
@@ -887,7 +633,7 @@ func (client *Client) AccountCreate(creator, newAccountName, publicKey, fee stri
 
 	jsonMeta := &types.AccountMetadata{}
 	tx := &types.AccountCreateOperation{
-		Fee:            fee,
+		Fee:            feeAsset,
 		Creator:        creator,
 		NewAccountName: newAccountName,
 		Owner:          &owner,
@@ -895,19 +641,24 @@ func (client *Client) AccountCreate(creator, newAccountName, publicKey, fee stri
 	}
 
 	trx = append(trx, tx)
-	resp, err := client.SendTrx(trx, "")
+	resp, err := client.SendTrxContext(ctx, trx, "")
 	return &OperResp{NameOper: "AccountCreate", Bresp: resp}, err
 }
 
 //AccountUpdate update public key for account
 func (client *Client) AccountUpdate(account, publicKey, fee string) (*OperResp, error) {
+	return client.AccountUpdateContext(context.Background(), account, publicKey, fee)
+}
+
+//AccountUpdateContext is AccountUpdate with the node requests bound to ctx.
+func (client *Client) AccountUpdateContext(ctx context.Context, account, publicKey, fee string) (*OperResp, error) {
 	err := ValidateNameAccount(account)
 	if err != nil {
 		return nil, err
 	}
-	validate := ValidateFee(fee, config.MIN_TRANSACTION_FEE)
-	if validate == false {
-		return nil, errors.New("Fee is not valid")
+	feeAsset, err := parseFee(fee, config.MIN_TRANSACTION_FEE)
+	if err != nil {
+		return nil, err
 	}
 
 	var trx []types.Operation
@@ -923,22 +674,27 @@ func (client *Client) AccountUpdate(account, publicKey, fee string) (*OperResp,
 		Account:      account,
 		Owner:        &owner,
 		JSONMetadata: jsonMeta,
-		Fee:          fee,
+		Fee:          feeAsset,
 	}
 
 	trx = append(trx, tx)
-	resp, err := client.SendTrx(trx, "")
+	resp, err := client.SendTrxContext(ctx, trx, "")
 	return &OperResp{NameOper: "AccountUpdate", Bresp: resp}, err
 }
 
 func (client *Client) AccountCreateWS(creator, newAccountName, password, fee string) (*OperResp, error) {
+	return client.AccountCreateWSContext(context.Background(), creator, newAccountName, password, fee)
+}
+
+//AccountCreateWSContext is AccountCreateWS with the node requests bound to ctx.
+func (client *Client) AccountCreateWSContext(ctx context.Context, creator, newAccountName, password, fee string) (*OperResp, error) {
 	err := ValidateNameAccount(newAccountName)
 	if err != nil {
 		return nil, err
 	}
-	validate := ValidateFee(fee, config.MIN_ACCOUNT_CREATION_FEE)
-	if validate == false {
-		return nil, errors.New("Fee is not valid")
+	feeAsset, err := parseFee(fee, config.MIN_ACCOUNT_CREATION_FEE)
+	if err != nil {
+		return nil, err
 	}
 	type Keys struct {
 		Private string
@@ -964,7 +720,7 @@ func (client *Client) AccountCreateWS(creator, newAccountName, password, fee str
 
 	jsonMeta := &types.AccountMetadata{}
 	tx := &types.AccountCreateOperation{
-		Fee:            fee,
+		Fee:            feeAsset,
 		Creator:        creator,
 		NewAccountName: newAccountName,
 		Owner:          &owner,
@@ -972,20 +728,26 @@ func (client *Client) AccountCreateWS(creator, newAccountName, password, fee str
 	}
 
 	trx = append(trx, tx)
-	resp, err := client.SendTrx(trx, "")
+	resp, err := client.SendTrxContext(ctx, trx, "")
 	return &OperResp{NameOper: "AccountCreateWS", Bresp: resp}, err
 }
 
 //CreateMultiSigAccount creating an account shared among many users in systems
 func (client *Client) CreateMultiSigAccount(creator, newAccountName, fee string, accountOwners []string, keyOwners []string,
+	threshold uint32) (*OperResp, error) {
+	return client.CreateMultiSigAccountContext(context.Background(), creator, newAccountName, fee, accountOwners, keyOwners, threshold)
+}
+
+//CreateMultiSigAccountContext is CreateMultiSigAccount with the node requests bound to ctx.
+func (client *Client) CreateMultiSigAccountContext(ctx context.Context, creator, newAccountName, fee string, accountOwners []string, keyOwners []string,
 	threshold uint32) (*OperResp, error) {
 	err := ValidateNameAccount(newAccountName)
 	if err != nil {
 		return nil, err
 	}
-	validate := ValidateFee(fee, config.MIN_ACCOUNT_CREATION_FEE)
-	if validate == false {
-		return nil, errors.New("Fee is not valid")
+	feeAsset, err := parseFee(fee, config.MIN_ACCOUNT_CREATION_FEE)
+	if err != nil {
+		return nil, err
 	}
 	if len(keyOwners)+len(accountOwners) == 0 {
 		return nil, errors.New("accountOwners + keyOwners is not empty")
@@ -1018,7 +780,7 @@ func (client *Client) CreateMultiSigAccount(creator, newAccountName, fee string,
 	}
 	jsonMeta := &types.AccountMetadata{}
 	tx := &types.AccountCreateOperation{
-		Fee:            fee,
+		Fee:            feeAsset,
 		Creator:        creator,
 		NewAccountName: newAccountName,
 		Owner:          &owner,
@@ -1026,20 +788,25 @@ func (client *Client) CreateMultiSigAccount(creator, newAccountName, fee string,
 	}
 
 	trx = append(trx, tx)
-	resp, err := client.SendTrx(trx, "")
+	resp, err := client.SendTrxContext(ctx, trx, "")
 	return &OperResp{NameOper: "AccountCreate", Bresp: resp}, err
 }
 
 //AccountUpdate update owner keys for account
 //TODO: every key has different weight on account
 func (client *Client) UpdateMultiSigAccount(account, fee string, accountOwners []string, keyOwners []string, threshold uint32) (*OperResp, error) {
+	return client.UpdateMultiSigAccountContext(context.Background(), account, fee, accountOwners, keyOwners, threshold)
+}
+
+//UpdateMultiSigAccountContext is UpdateMultiSigAccount with the node requests bound to ctx.
+func (client *Client) UpdateMultiSigAccountContext(ctx context.Context, account, fee string, accountOwners []string, keyOwners []string, threshold uint32) (*OperResp, error) {
 	err := ValidateNameAccount(account)
 	if err != nil {
 		return nil, err
 	}
-	validate := ValidateFee(fee, config.MIN_TRANSACTION_FEE)
-	if validate == false {
-		return nil, errors.New("Fee is not valid")
+	feeAsset, err := parseFee(fee, config.MIN_TRANSACTION_FEE)
+	if err != nil {
+		return nil, err
 	}
 	if len(keyOwners)+len(accountOwners) == 0 {
 		return nil, errors.New("accountOwners + keyOwners is not empty")
@@ -1075,46 +842,56 @@ func (client *Client) UpdateMultiSigAccount(account, fee string, accountOwners [
 		Account:      account,
 		Owner:        &owner,
 		JSONMetadata: jsonMeta,
-		Fee:          fee,
+		Fee:          feeAsset,
 	}
 
 	trx = append(trx, tx)
-	resp, err := client.SendTrx(trx, "")
+	resp, err := client.SendTrxContext(ctx, trx, "")
 	return &OperResp{NameOper: "AccountUpdate", Bresp: resp}, err
 }
 
 func (client *Client) CreateTrxTransfer(fromName, toName, memo, amount, fee string, extension string) (*transactions.SignedTransaction, error) {
-	validate := ValidateFee(fee, config.MIN_TRANSACTION_FEE)
-	if validate == false {
-		return nil, errors.New("Fee is not valid")
+	return client.CreateTrxTransferContext(context.Background(), fromName, toName, memo, amount, fee, extension)
+}
+
+//CreateTrxTransferContext is CreateTrxTransfer with the node requests bound to ctx.
+func (client *Client) CreateTrxTransferContext(ctx context.Context, fromName, toName, memo, amount, fee string, extension string) (*transactions.SignedTransaction, error) {
+	feeAsset, err := parseFee(fee, config.MIN_TRANSACTION_FEE)
+	if err != nil {
+		return nil, err
 	}
-	validate = ValidateAmount(amount)
-	if validate == false {
-		return nil, errors.New("Amount is not valid")
+	amountAsset, err := parseAmount(amount)
+	if err != nil {
+		return nil, err
 	}
 	var trxOps []types.Operation
 	tOp := &types.TransferOperation{
 		From:   fromName,
 		To:     toName,
-		Amount: amount,
-		Fee:    fee,
+		Amount: amountAsset,
+		Fee:    feeAsset,
 		Memo:   memo,
 	}
 	trxOps = append(trxOps, tOp)
 
 	// CreateTrx
-	tx, err := client.CreateTrx(trxOps, extension)
+	tx, err := client.CreateTrxContext(ctx, trxOps, extension)
 
 	return tx, err
 }
 
 func (client *Client) CreateTrx(trxOps []types.Operation, extension string) (*transactions.SignedTransaction, error) {
+	return client.CreateTrxContext(context.Background(), trxOps, extension)
+}
+
+//CreateTrxContext is CreateTrx with the node requests bound to ctx.
+func (client *Client) CreateTrxContext(ctx context.Context, trxOps []types.Operation, extension string) (*transactions.SignedTransaction, error) {
 	// Getting the necessary parameters
-	refBlockNum, err := client.GetHeadBlockNum()
+	refBlockNum, err := client.GetHeadBlockNumContext(ctx)
 	if err != nil {
 		return nil, err
 	}
-	block, err := client.API.GetBlock(refBlockNum)
+	block, err := client.API.GetBlockContext(ctx, refBlockNum)
 	if err != nil {
 		return nil, err
 	}
@@ -1128,8 +905,8 @@ func (client *Client) CreateTrx(trxOps []types.Operation, extension string) (*tr
 	ex := []interface{}{}
 	if len(extension) > 0 {
 		ex = make([]interface{}, 1)
-		as := types.ExtensionJsonType{extension}
-		tas := types.ExtensionType{uint8(types.ExtJsonType.Code()), as}
+		as := types.ExtensionJsonType{Data: extension}
+		tas := types.ExtensionType{Type: uint8(types.ExtJsonType.Code()), Value: as}
 		ex[0] = &tas
 	}
 
@@ -1160,13 +937,13 @@ func (client *Client) CreateTrx(trxOps []types.Operation, extension string) (*tr
 
 func (client *Client) SignTrx(tx *transactions.SignedTransaction) (*transactions.SignedTransaction, error) {
 	// Obtain the key required for signing
-	privKeys, err := client.GetSigningKeysOwner()
+	signers, err := client.SignersOwner()
 	if err != nil {
 		return nil, err
 	}
 
 	// Sign the transaction
-	txId, err := tx.Sign(privKeys, client.chainID)
+	txId, err := tx.SignWith(signers, client.chainID)
 	if err != nil || txId == "" {
 		return nil, err
 	}
@@ -1176,13 +953,13 @@ func (client *Client) SignTrx(tx *transactions.SignedTransaction) (*transactions
 func (client *Client) SignTrxMulti(tx *transactions.SignedTransaction) ([]string, error) {
 	var sigsHex []string
 	// Obtain the key required for signing
-	privKeys, err := client.GetSigningKeysOwner()
+	signers, err := client.SignersOwner()
 	if err != nil {
 		return sigsHex, err
 	}
 
 	// Sign the transaction
-	sigsHex, err = tx.SignMulti(privKeys, client.chainID)
+	sigsHex, err = tx.SignMultiWith(signers, client.chainID)
 	if err != nil {
 		return sigsHex, err
 	}
@@ -1190,18 +967,23 @@ func (client *Client) SignTrxMulti(tx *transactions.SignedTransaction) ([]string
 }
 
 func (client *Client) SendTrxMultiSig(tx *transactions.SignedTransaction) (*BResp, error) {
+	return client.SendTrxMultiSigContext(context.Background(), tx)
+}
+
+//SendTrxMultiSigContext is SendTrxMultiSig with the node requests bound to ctx.
+func (client *Client) SendTrxMultiSigContext(ctx context.Context, tx *transactions.SignedTransaction) (*BResp, error) {
 	var bresp BResp
 	var err error
 	// Sending a transaction
 	if client.AsyncProtocol {
 		var resp *api.AsyncBroadcastResponse
-		resp, err = client.API.BroadcastTransaction(tx.Transaction)
+		resp, err = client.API.BroadcastTransactionContext(ctx, tx.Transaction)
 		if resp != nil {
 			bresp.ID = resp.ID
 		}
 	} else {
 		var resp *api.BroadcastResponse
-		resp, err = client.API.BroadcastTransactionSynchronous(tx.Transaction)
+		resp, err = client.API.BroadcastTransactionSynchronousContext(ctx, tx.Transaction)
 		if resp != nil {
 			bresp.ID = resp.ID
 		}
@@ -1232,31 +1014,35 @@ func ValidateNameAccount(name string) error {
 }
 
 func ValidateFee(fee string, minFee float64) bool {
-	//Validate format of fee
-	amount := strings.Split(fee, " ")[0]
-	symbol := strings.Split(fee, " ")[1]
-	if symbol != config.WD_SYMBOL {
-		return false
-	}
-	//Parse fee to float
-	amt, err := strconv.ParseFloat(amount, 64)
-	if err != nil {
-		return false
-	}
-	if amt < minFee {
-		return false
-	}
-	return true
+	_, err := parseFee(fee, minFee)
+	return err == nil
 }
 
 func ValidateAmount(amount string) bool {
-	amtStr := strings.Split(amount, " ")[0]
-	amt, err := strconv.ParseFloat(amtStr, 64)
-	if err != nil {
-		return false
+	_, err := parseAmount(amount)
+	return err == nil
+}
+
+//parseFee parses the fee into an exact Asset, it must be paid in WD_SYMBOL at WD_PRECISION and be at least minFee
+func parseFee(fee string, minFee float64) (*types.Asset, error) {
+	feeAsset, err := types.ParseAsset(fee)
+	if err != nil || feeAsset.Symbol != config.WD_SYMBOL || feeAsset.Precision != config.WD_PRECISION {
+		return nil, errors.New("Fee is not valid")
 	}
-	if amt <= 0 {
-		return false
+	// the minimum is built at the precision of the chain, a fee with fewer decimals would round it down
+	scale := math.Pow10(config.WD_PRECISION)
+	min := types.NewAsset(int64(math.Round(minFee*scale)), config.WD_PRECISION, config.WD_SYMBOL)
+	if cmp, err := feeAsset.Cmp(min); err != nil || cmp < 0 {
+		return nil, errors.New("Fee is not valid")
+	}
+	return feeAsset, nil
+}
+
+//parseAmount parses the amount into an exact Asset, it must be positive
+func parseAmount(amount string) (*types.Asset, error) {
+	amountAsset, err := types.ParseAsset(amount)
+	if err != nil || amountAsset.Sign() <= 0 {
+		return nil, errors.New("Amount is not valid")
 	}
-	return true
+	return amountAsset, nil
 }
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/thanhxeon2470/beowulf-go/config"
+	"github.com/thanhxeon2470/beowulf-go/mocknode"
+)
+
+func TestValidateFee(t *testing.T) {
+	tests := []struct {
+		fee   string
+		valid bool
+	}{
+		{"0.01000 W", true},
+		{"1.00000 W", true},
+		{"0 W", false},
+		{"0.0 W", false},
+		{"0.00 W", false},
+		{"0.00999 W", false},
+		{"0.01 W", false},
+		{"0.010000 W", false},
+		{"0.01000 BWF", false},
+	}
+	for _, test := range tests {
+		if valid := ValidateFee(test.fee, config.MIN_TRANSACTION_FEE); valid != test.valid {
+			t.Errorf("ValidateFee(%q) = %v", test.fee, valid)
+		}
+	}
+}
+
+func TestTransferContext(t *testing.T) {
+	node := mocknode.New(mocknode.WithBlockInterval(0))
+	defer node.Close()
+	wif := CreatePrivateKey("alice", "owner", "password")
+	if err := node.CreateAccount("alice", CreatePublicKey(config.ADDRESS_PREFIX, wif), "10.00000 W"); err != nil {
+		t.Fatal(err)
+	}
+	if err := node.CreateAccount("bob", CreatePublicKey(config.ADDRESS_PREFIX, wif)); err != nil {
+		t.Fatal(err)
+	}
+	cls, err := NewClient(node.URL(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cls.Close()
+	cls.SetKeys(&Keys{OKey: []string{wif}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := cls.TransferContext(ctx, "alice", "bob", "", "1.00000 W", "0.01000 W"); !errors.Is(err, context.Canceled) {
+		t.Errorf("transfer with a canceled context: %v", err)
+	}
+	if _, err := cls.Transfer("alice", "bob", "", "2.00000 W", "0.01000 W"); err != nil {
+		t.Fatal(err)
+	}
+	node.ProduceBlock()
+	if asset, err := node.Balance("bob", mocknode.SymbolW); err != nil || asset.String() != "2.00000 W" {
+		t.Errorf("bob has %v, %v", asset, err)
+	}
+}
@@ -5,9 +5,9 @@ import (
 	"github.com/thanhxeon2470/beowulf-go/types"
 )
 
-//SetAsset returns data of type Asset
-func SetAsset(amount float64, symbol string) *types.Asset {
-	return &types.Asset{Amount: amount, Symbol: symbol}
+//SetAsset returns data of type Asset, amount is counted in units of 10^-precision symbol
+func SetAsset(amount int64, precision uint8, symbol string) *types.Asset {
+	return types.NewAsset(amount, precision, symbol)
 }
 
 //JSONTrxString generate Trx to String
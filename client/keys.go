@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/thanhxeon2470/beowulf-go/config"
 	"github.com/thanhxeon2470/beowulf-go/encoding/wif"
+	"github.com/thanhxeon2470/beowulf-go/transactions"
 	"github.com/thanhxeon2470/beowulf-go/types"
 	"golang.org/x/crypto/ripemd160"
 )
@@ -76,6 +77,36 @@ func (client *Client) GetPublicKey() string {
 	return ""
 }
 
+//SetSigners makes the client sign the transactions with the given signers instead of
+//the private keys of CurrentKeys, e.g. with the keys of an external signer process.
+func (client *Client) SetSigners(signers ...transactions.Signer) {
+	client.signers = signers
+}
+
+//Signers returns the signers for the operation: the ones set with SetSigners, or the CurrentKeys
+func (client *Client) Signers(trx types.Operation) ([]transactions.Signer, error) {
+	if len(client.signers) > 0 {
+		return client.signers, nil
+	}
+	keys, err := client.SigningKeys(trx)
+	if err != nil {
+		return nil, err
+	}
+	return transactions.KeySigners(keys), nil
+}
+
+//SignersOwner returns the owner signers: the ones set with SetSigners, or the CurrentKeys
+func (client *Client) SignersOwner() ([]transactions.Signer, error) {
+	if len(client.signers) > 0 {
+		return client.signers, nil
+	}
+	keys, err := client.GetSigningKeysOwner()
+	if err != nil {
+		return nil, err
+	}
+	return transactions.KeySigners(keys), nil
+}
+
 //SigningKeys returns the key from the CurrentKeys
 func (client *Client) SigningKeys(trx types.Operation) ([][]byte, error) {
 	var keys [][]byte
@@ -122,8 +153,7 @@ func (client *Client) GetSigningKeysOwner() ([][]byte, error) {
 
 //CreatePrivateKey generates a private key based on the specified parameters.
 func CreatePrivateKey(user, role, password string) string {
-	new_password := password + Wallet_.Salt
-	hashSha256 := sha256.Sum256([]byte(user + role + new_password))
+	hashSha256 := sha256.Sum256([]byte(user + role + password))
 	pk := append([]byte{0x80}, hashSha256[:]...)
 	chs := sha256.Sum256(pk)
 	chs = sha256.Sum256(chs[:])
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/thanhxeon2470/beowulf-go/api"
+	"github.com/thanhxeon2470/beowulf-go/config"
+	"github.com/thanhxeon2470/beowulf-go/transactions"
+	"github.com/thanhxeon2470/beowulf-go/transports"
+	"github.com/thanhxeon2470/beowulf-go/types"
+)
+
+var (
+	ErrTransactionExpired = types.ErrTransactionExpired
+)
+
+//RebroadcastOption configures SendSignedTrx
+type RebroadcastOption func(*rebroadcast)
+
+type rebroadcast struct {
+	delay time.Duration
+}
+
+//WithRebroadcastDelay sets how long to wait before broadcasting the transaction again after a transient error.
+func WithRebroadcastDelay(delay time.Duration) RebroadcastOption {
+	return func(r *rebroadcast) {
+		r.delay = delay
+	}
+}
+
+//SendSignedTrx broadcasts a transaction signed beforehand, e.g. with CreateTrx and SignTrx, see SendSignedTrxContext.
+func (client *Client) SendSignedTrx(tx *transactions.SignedTransaction, opts ...RebroadcastOption) (*BResp, error) {
+	return client.SendSignedTrxContext(context.Background(), tx, opts...)
+}
+
+//SendSignedTrxContext broadcasts a transaction signed beforehand until a node has it. Unlike sending the
+//operations again, which makes a new transaction, the same transaction is broadcast again after a transient
+//error such as a timeout, so it is never applied twice: a duplicate transaction error means an earlier
+//broadcast reached the node. It stops once the node knows the transaction, when the node rejects it, when
+//it expired (ErrTransactionExpired) or when ctx is done. The id in BResp is computed locally.
+func (client *Client) SendSignedTrxContext(ctx context.Context, tx *transactions.SignedTransaction, opts ...RebroadcastOption) (*BResp, error) {
+	r := rebroadcast{delay: config.REBROADCAST_DELAY_SECOND * time.Second}
+	for _, opt := range opts {
+		opt(&r)
+	}
+	if tx.Expiration == nil || tx.Expiration.Time == nil {
+		return nil, errors.New("transaction has no expiration")
+	}
+	id, err := tx.ID()
+	if err != nil {
+		return nil, err
+	}
+	bresp := &BResp{ID: id}
+	bresp.JSONTrx, _ = JSONTrxString(tx)
+
+	for {
+		if client.AsyncProtocol {
+			_, err = client.API.BroadcastTransactionContext(ctx, tx.Transaction)
+		} else {
+			var resp *api.BroadcastResponse
+			resp, err = client.API.BroadcastTransactionSynchronousContext(ctx, tx.Transaction)
+			// the node waited for the transaction, which expired before being included
+			if err == nil && resp.Expired {
+				return bresp, ErrTransactionExpired
+			}
+		}
+		if err == nil || errors.Is(err, types.ErrDuplicateTransaction) {
+			return bresp, nil
+		}
+		if ctx.Err() != nil || transports.IsChainError(err) {
+			return bresp, err
+		}
+
+		// the broadcast may have reached the node before the error
+		if resp, serr := client.API.GetTransactionWithStatusContext(ctx, id); serr == nil {
+			switch resp.Status {
+			case api.TransactionStatusWithinMempool, api.TransactionStatusWithinReversibleBlock,
+				api.TransactionStatusWithinIrreversibleBlock:
+				return bresp, nil
+			case api.TransactionStatusExpiredReversible, api.TransactionStatusExpiredIrreversible:
+				return bresp, ErrTransactionExpired
+			}
+		}
+		// past its expiration the transaction can no longer be included, even when the status is unknown
+		if !tx.Expiration.Time.After(time.Now()) {
+			return bresp, ErrTransactionExpired
+		}
+
+		timer := time.NewTimer(r.delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return bresp, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/thanhxeon2470/beowulf-go/config"
+	"github.com/thanhxeon2470/beowulf-go/mocknode"
+	"github.com/thanhxeon2470/beowulf-go/transactions"
+	"github.com/thanhxeon2470/beowulf-go/transports"
+	"github.com/thanhxeon2470/beowulf-go/transports/http"
+	"github.com/thanhxeon2470/beowulf-go/types"
+)
+
+// flakyTransport fails the first broadcasts, after or instead of sending them
+type flakyTransport struct {
+	transports.CallCloser
+	delivered int // number of broadcasts to fail after sending them
+	dropped   int // number of broadcasts to fail without sending them
+	sent      int
+}
+
+func (f *flakyTransport) CallContext(ctx context.Context, method string, args []interface{}, reply interface{}, scid string) error {
+	if len(args) < 2 || args[1] != "broadcast_transaction" {
+		return f.CallCloser.CallContext(ctx, method, args, reply, scid)
+	}
+	f.sent++
+	if f.dropped > 0 {
+		f.dropped--
+		return errors.New("i/o timeout")
+	}
+	err := f.CallCloser.CallContext(ctx, method, args, reply, scid)
+	if f.delivered > 0 {
+		f.delivered--
+		return errors.New("i/o timeout")
+	}
+	return err
+}
+
+// downTransport fails every call without sending it
+type downTransport struct {
+	transports.CallCloser
+}
+
+func (d *downTransport) CallContext(ctx context.Context, method string, args []interface{}, reply interface{}, scid string) error {
+	return errors.New("connection refused")
+}
+
+func TestSendSignedTrx(t *testing.T) {
+	node := mocknode.New(mocknode.WithBlockInterval(0))
+	defer node.Close()
+	wif := CreatePrivateKey("alice", "owner", "password")
+	if err := node.CreateAccount("alice", CreatePublicKey(config.ADDRESS_PREFIX, wif), "10.00000 W"); err != nil {
+		t.Fatal(err)
+	}
+	if err := node.CreateAccount("bob", CreatePublicKey(config.ADDRESS_PREFIX, wif)); err != nil {
+		t.Fatal(err)
+	}
+	caller, err := http.NewTransport(node.URL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	flaky := &flakyTransport{CallCloser: caller, delivered: 1}
+	cls := NewClientWithTransport(flaky, true)
+	defer cls.Close()
+	cls.SetKeys(&Keys{OKey: []string{wif}})
+
+	send := func(amount string) *BResp {
+		tx, err := cls.CreateTrxTransfer("alice", "bob", "", amount, "0.01000 W", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := cls.SignTrx(tx); err != nil {
+			t.Fatal(err)
+		}
+		resp, err := cls.SendSignedTrx(tx, WithRebroadcastDelay(time.Millisecond))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// a duplicate is not an error
+		if _, err := cls.SendSignedTrx(tx); err != nil {
+			t.Errorf("rebroadcast: %v", err)
+		}
+		return resp
+	}
+
+	// the first broadcast reached the node
+	resp := send("1.00000 W")
+	if flaky.sent != 2 {
+		t.Errorf("broadcast %d times", flaky.sent)
+	}
+	// the first broadcast got lost
+	flaky.sent, flaky.dropped = 0, 2
+	send("2.00000 W")
+	if flaky.sent != 4 {
+		t.Errorf("broadcast %d times", flaky.sent)
+	}
+
+	node.ProduceBlock()
+	if tx, err := cls.API.GetTransaction(resp.ID); err != nil || tx.TransactionId != resp.ID {
+		t.Errorf("transaction %+v, %v", tx, err)
+	}
+	if asset, err := node.Balance("bob", mocknode.SymbolW); err != nil || asset.String() != "3.00000 W" {
+		t.Errorf("bob has %v, %v", asset, err)
+	}
+}
+
+func TestSendSignedTrxExpired(t *testing.T) {
+	node := mocknode.New(mocknode.WithBlockInterval(0))
+	defer node.Close()
+	wif := CreatePrivateKey("alice", "owner", "password")
+	if err := node.CreateAccount("alice", CreatePublicKey(config.ADDRESS_PREFIX, wif), "10.00000 W"); err != nil {
+		t.Fatal(err)
+	}
+	if err := node.CreateAccount("bob", CreatePublicKey(config.ADDRESS_PREFIX, wif)); err != nil {
+		t.Fatal(err)
+	}
+	caller, err := http.NewTransport(node.URL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cls := NewClientWithTransport(caller, true)
+	defer cls.Close()
+	cls.SetKeys(&Keys{OKey: []string{wif}})
+
+	create := func(amount string, expiration time.Time) *transactions.SignedTransaction {
+		tx, err := cls.CreateTrxTransfer("alice", "bob", "", amount, "0.01000 W", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		tx.Expiration = &types.Time{Time: &expiration}
+		if _, err := cls.SignTrx(tx); err != nil {
+			t.Fatal(err)
+		}
+		return tx
+	}
+
+	// the synchronous broadcast answers once the transaction expired in a block
+	expiration := time.Now().Add(2 * time.Second).Truncate(time.Second).UTC()
+	tx := create("1.00000 W", expiration)
+	go func() {
+		time.Sleep(time.Until(expiration) + 100*time.Millisecond)
+		node.ProduceBlock()
+	}()
+	cls.AsyncProtocol = false
+	if _, err := cls.SendSignedTrx(tx); !errors.Is(err, ErrTransactionExpired) {
+		t.Errorf("synchronous broadcast: %v, expected ErrTransactionExpired", err)
+	}
+
+	// the node cannot be reached to tell the status of the transaction
+	tx = create("2.00000 W", time.Now().Add(-time.Second).Truncate(time.Second).UTC())
+	down := NewClientWithTransport(&downTransport{CallCloser: caller}, true)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := down.SendSignedTrxContext(ctx, tx, WithRebroadcastDelay(time.Millisecond)); !errors.Is(err, ErrTransactionExpired) {
+		t.Errorf("broadcast without node: %v, expected ErrTransactionExpired", err)
+	}
+	if asset, err := node.Balance("bob", mocknode.SymbolW); err != nil || asset.String() != "0.00000 W" {
+		t.Errorf("bob has %v, %v", asset, err)
+	}
+}
@@ -1,10 +1,12 @@
 package client
 
 import (
+	"context"
 	"time"
 
 	"github.com/thanhxeon2470/beowulf-go/api"
 	"github.com/thanhxeon2470/beowulf-go/config"
+	"github.com/thanhxeon2470/beowulf-go/stream"
 	"github.com/thanhxeon2470/beowulf-go/transactions"
 	"github.com/thanhxeon2470/beowulf-go/types"
 )
@@ -25,13 +27,18 @@ type OperResp struct {
 
 //Get HeadBlockNumber from mem before getting from Blockchain
 func (client *Client) GetHeadBlockNum() (uint32, error) {
+	return client.GetHeadBlockNumContext(context.Background())
+}
+
+//GetHeadBlockNumContext is GetHeadBlockNum with the node requests bound to ctx.
+func (client *Client) GetHeadBlockNumContext(ctx context.Context) (uint32, error) {
 	if len(RefBlockMap) > 0 {
 		for k := range RefBlockMap {
 			old := k.Add(config.GET_HEAD_BLOCK_NUM_TIMEOUT_IN_MIN * time.Minute)
 			now := time.Now().UTC()
 			if old.Before(now) {
 				delete(RefBlockMap, k)
-				props, err := client.API.GetDynamicGlobalProperties()
+				props, err := client.API.GetDynamicGlobalPropertiesContext(ctx)
 				if err != nil {
 					return 0, err
 				}
@@ -45,7 +52,7 @@ func (client *Client) GetHeadBlockNum() (uint32, error) {
 			return RefBlockMap[k], nil
 		}
 	}
-	props, err := client.API.GetDynamicGlobalProperties()
+	props, err := client.API.GetDynamicGlobalPropertiesContext(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -60,14 +67,19 @@ func (client *Client) GetHeadBlockNum() (uint32, error) {
 
 //SendTrx generates and sends an array of transactions to BEOWULF.
 func (client *Client) SendTrx(strx []types.Operation, extension string) (*BResp, error) {
+	return client.SendTrxContext(context.Background(), strx, extension)
+}
+
+//SendTrxContext generates and sends an array of transactions to BEOWULF, the node requests are bound to ctx.
+func (client *Client) SendTrxContext(ctx context.Context, strx []types.Operation, extension string) (*BResp, error) {
 	var bresp BResp
 
 	// Getting the necessary parameters
-	refBlockNum, err := client.GetHeadBlockNum()
+	refBlockNum, err := client.GetHeadBlockNumContext(ctx)
 	if err != nil {
 		return nil, err
 	}
-	block, err := client.API.GetBlock(refBlockNum)
+	block, err := client.API.GetBlockContext(ctx, refBlockNum)
 	if err != nil {
 		return nil, err
 	}
@@ -81,8 +93,8 @@ func (client *Client) SendTrx(strx []types.Operation, extension string) (*BResp,
 	ex := []interface{}{}
 	if len(extension) > 0 {
 		ex = make([]interface{}, 1)
-		as := types.ExtensionJsonType{extension}
-		tas := types.ExtensionType{uint8(types.ExtJsonType.Code()), as}
+		as := types.ExtensionJsonType{Data: extension}
+		tas := types.ExtensionType{Type: uint8(types.ExtJsonType.Code()), Value: as}
 		ex[0] = &tas
 	}
 
@@ -107,14 +119,14 @@ func (client *Client) SendTrx(strx []types.Operation, extension string) (*BResp,
 	tx.CreatedTime = types.UInt64(createdTime.Unix())
 
 	// Obtain the key required for signing
-	privKeys, err := client.SigningKeys(strx[0])
+	signers, err := client.Signers(strx[0])
 	if err != nil {
 		return nil, err
 	}
 
 	// Sign the transaction
 	tx.Transaction.Signatures = []string{}
-	txId, err := tx.Sign(privKeys, client.chainID)
+	txId, err := tx.SignWith(signers, client.chainID)
 	if err != nil || txId == "" {
 		return nil, err
 	}
@@ -122,13 +134,13 @@ func (client *Client) SendTrx(strx []types.Operation, extension string) (*BResp,
 	// Sending a transaction
 	if client.AsyncProtocol {
 		var resp *api.AsyncBroadcastResponse
-		resp, err = client.API.BroadcastTransaction(tx.Transaction)
+		resp, err = client.API.BroadcastTransactionContext(ctx, tx.Transaction)
 		if resp != nil {
 			bresp.ID = resp.ID
 		}
 	} else {
 		var resp *api.BroadcastResponse
-		resp, err = client.API.BroadcastTransactionSynchronous(tx.Transaction)
+		resp, err = client.API.BroadcastTransactionSynchronousContext(ctx, tx.Transaction)
 		if resp != nil {
 			bresp.ID = resp.ID
 		}
@@ -144,12 +156,17 @@ func (client *Client) SendTrx(strx []types.Operation, extension string) (*BResp,
 }
 
 func (client *Client) GetTrx(strx []types.Operation, extension string) (*types.Transaction, error) {
+	return client.GetTrxContext(context.Background(), strx, extension)
+}
+
+//GetTrxContext is GetTrx with the node requests bound to ctx.
+func (client *Client) GetTrxContext(ctx context.Context, strx []types.Operation, extension string) (*types.Transaction, error) {
 	// Getting the necessary parameters
-	refBlockNum, err := client.GetHeadBlockNum()
+	refBlockNum, err := client.GetHeadBlockNumContext(ctx)
 	if err != nil {
 		return nil, err
 	}
-	block, err := client.API.GetBlock(refBlockNum)
+	block, err := client.API.GetBlockContext(ctx, refBlockNum)
 	if err != nil {
 		return nil, err
 	}
@@ -163,8 +180,8 @@ func (client *Client) GetTrx(strx []types.Operation, extension string) (*types.T
 	ex := []interface{}{}
 	if len(extension) > 0 {
 		ex = make([]interface{}, 1)
-		as := types.ExtensionJsonType{extension}
-		tas := types.ExtensionType{uint8(types.ExtJsonType.Code()), as}
+		as := types.ExtensionJsonType{Data: extension}
+		tas := types.ExtensionType{Type: uint8(types.ExtJsonType.Code()), Value: as}
 		ex[0] = &tas
 	}
 
@@ -191,3 +208,9 @@ func (client *Client) GetTrx(strx []types.Operation, extension string) (*types.T
 
 	return tx, nil
 }
+
+//WaitForTransaction waits until the transaction with the id returned by SendTrx reaches the level
+//or expires, use a stream.Tracker to watch many transactions at once.
+func (client *Client) WaitForTransaction(ctx context.Context, id string, level stream.Level) (*stream.Confirmation, error) {
+	return stream.WaitForTransaction(ctx, client.API, id, level)
+}
@@ -2,6 +2,8 @@ package client
 
 import (
 	"github.com/thanhxeon2470/beowulf-go/config"
+	"github.com/thanhxeon2470/beowulf-go/encoding/wif"
+	"github.com/thanhxeon2470/beowulf-go/hdwallet"
 	"crypto/rand"
 	"crypto/sha512"
 	"encoding/json"
@@ -9,15 +11,13 @@ import (
 	"io/ioutil"
 	"math/big"
 	"os"
+	"sort"
+	"sync"
+	"time"
 )
 
-var (
-	Keys_       = make(map[string]string)
-	Checksum_   = make([]byte, sha512.Size)
-	Wallet_     Wallet
-	Locked      = true
-	WalletName_ = "wallet.json"
-)
+//DefaultWalletName is the wallet file used when no file name is given
+const DefaultWalletName = "wallet.json"
 
 const letterBytes = "0123456789+abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
 
@@ -43,25 +43,20 @@ func RandStringBytes(n int) (string, error) {
 	return string(ret), nil
 }
 
-func allZero(s []byte) bool {
-	for _, v := range s {
-		if v != 0 {
-			return false
-		}
-	}
-	return true
-}
-
+//PlainKeys is the plaintext of a legacy wallet
 type PlainKeys struct {
 	Checksum [sha512.Size]byte `json:"checksum"`
 	Keys     map[string]string `json:"keys"`
 }
 
-type Wallet struct {
-	CipherKeys string `json:"cipher_keys"`
-	CipherType string `json:"cipher_type"` // "aes-256-cbc"
-	Salt       string `json:"salt"`
-	Name       string `json:"name"`
+//WalletFile is the stored form of a wallet
+type WalletFile struct {
+	Version    int        `json:"version,omitempty"` // absent in legacy wallets
+	CipherKeys string     `json:"cipher_keys"`
+	CipherType string     `json:"cipher_type"` // "aes-256-gcm", legacy "aes-256-cbc"
+	Salt       string     `json:"salt"`
+	Kdf        *KdfParams `json:"kdf,omitempty"`
+	Name       string     `json:"name"`
 }
 
 type WalletData struct {
@@ -70,163 +65,442 @@ type WalletData struct {
 	PublicKey  string `json:"public_key"`
 }
 
-func isNew() bool {
-	return len(Wallet_.CipherKeys) == 0
+//WalletKey describes a key of a Wallet
+type WalletKey struct {
+	PublicKey string `json:"public_key"`
+	Label     string `json:"label,omitempty"`
+}
+
+var (
+	ErrWalletLocked      = errors.New("The wallet must be unlocked")
+	ErrWalletKeyNotFound = errors.New("The wallet has no such key")
+)
+
+//Wallet holds the keys of one wallet file, it is safe for concurrent use.
+//The keys are only held in memory while the wallet is unlocked.
+type Wallet struct {
+	mutex sync.Mutex
+	path  string
+	file  WalletFile
+
+	// key is the encryption key derived from the password, nil while locked
+	key  []byte
+	keys walletKeys
+
+	lockTimeout time.Duration
+	lockTimer   *time.Timer
+}
+
+//NewWallet creates an empty wallet that is stored at path, set its password before adding keys.
+func NewWallet(path string) *Wallet {
+	if path == "" {
+		path = DefaultWalletName
+	}
+	return &Wallet{path: path}
+}
+
+//OpenWallet reads the wallet stored at path, it is locked.
+func OpenWallet(path string) (*Wallet, error) {
+	if path == "" {
+		path = DefaultWalletName
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	wl, err := parseWalletFile(data)
+	if err != nil {
+		return nil, err
+	}
+	return &Wallet{path: path, file: *wl}, nil
 }
 
-func isLocked() bool {
-	return allZero(Checksum_)
+//Path returns the file the wallet is saved to.
+func (w *Wallet) Path() string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.path
 }
 
-func lock() error {
-	if isLocked() {
+//Name returns the name stored in the wallet file.
+func (w *Wallet) Name() string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.file.Name
+}
+
+//SetName sets the name stored in the wallet file.
+func (w *Wallet) SetName(name string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.file.Name = name
+}
+
+//IsNew reports whether the wallet has no password yet.
+func (w *Wallet) IsNew() bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.isNew()
+}
+
+func (w *Wallet) isNew() bool {
+	return len(w.file.CipherKeys) == 0
+}
+
+//IsLocked reports whether the keys of the wallet are unavailable.
+func (w *Wallet) IsLocked() bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.key == nil
+}
+
+//SetLockTimeout makes the wallet lock itself the given time after every Unlock, 0 disables it.
+func (w *Wallet) SetLockTimeout(timeout time.Duration) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.lockTimeout = timeout
+}
+
+//SetPassword encrypts the wallet with a new password and locks it. A wallet
+//that is not new must be unlocked.
+func (w *Wallet) SetPassword(password string) error {
+	if len(password) == 0 {
+		return errors.New("Password must be not empty")
+	}
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if !w.isNew() && w.key == nil {
 		return errors.New("The wallet must be unlocked before the password can be set")
 	}
-	err := encryptKeys()
+	key, err := w.file.init(password)
 	if err != nil {
 		return err
 	}
-	for k := range Keys_ {
-		Keys_[k] = ""
+	if err := w.file.sealKeys(key, w.keys); err != nil {
+		return err
 	}
-	Keys_ = make(map[string]string)
-	Checksum_ = Checksum_[:0]
-	Locked = true
+	w.lock()
 	return nil
 }
 
-func (client *Client) Unlock(password string) error {
+//Unlock decrypts the keys of the wallet. A legacy wallet is written in the current
+//format on its next Save.
+func (w *Wallet) Unlock(password string) error {
 	if len(password) == 0 {
 		return errors.New("Password must be not empty")
 	}
-	new_password := password + Wallet_.Salt
-	pw := sha512.Sum512([]byte(new_password))
-	decrypted, err := Decrypt(pw[:], Wallet_.CipherKeys)
-	if err != nil {
-		return err
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.unlock(password)
+}
+
+func (w *Wallet) unlock(password string) error {
+	if w.isNew() {
+		return errors.New("The wallet has no password yet")
 	}
-	var pk PlainKeys
-	err = json.Unmarshal([]byte(decrypted), &pk)
+	keys, key, err := w.file.decryptKeys(password)
 	if err != nil {
 		return err
 	}
-	if pw != pk.Checksum {
-		return errors.New("Don't match checksum")
+	if w.file.version() == WalletVersionLegacy {
+		if key, err = w.file.init(password); err != nil {
+			return err
+		}
+		if err := w.file.sealKeys(key, keys); err != nil {
+			return err
+		}
 	}
-	Keys_ = pk.Keys
-	Checksum_ = pk.Checksum[:]
-	Locked = false
-	//Set keys
-	for k := range pk.Keys {
-		client.SetKeys(&Keys{OKey: []string{pk.Keys[k]}})
+	if keys.Keys == nil {
+		keys.Keys = make(map[string]string)
+	}
+	w.key = key
+	w.keys = keys
+
+	w.stopTimer()
+	if w.lockTimeout > 0 {
+		var timer *time.Timer
+		timer = time.AfterFunc(w.lockTimeout, func() {
+			w.mutex.Lock()
+			defer w.mutex.Unlock()
+			// a timer that fired while the wallet was unlocked again must not lock it
+			if w.lockTimer == timer {
+				w.lock()
+			}
+		})
+		w.lockTimer = timer
 	}
 	return nil
 }
 
-func (client *Client) SetPassword(password string) error {
-	if !isNew() {
-		if isLocked() {
-			return errors.New("The wallet must be unlocked before the password can be set")
-		}
+//Lock drops the keys from memory.
+func (w *Wallet) Lock() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.lock()
+}
+
+func (w *Wallet) lock() {
+	w.stopTimer()
+	for k := range w.keys.Keys {
+		w.keys.Keys[k] = ""
 	}
-	salt, err := RandStringBytes(16)
-	if err != nil {
-		// Serve an appropriately vague error to the
-		// user, but log the details internally.
-		panic(err)
+	for i := range w.key {
+		w.key[i] = 0
 	}
-	new_password := password + salt
-	c := sha512.Sum512([]byte(new_password))
-	Checksum_ = c[:]
-	Wallet_.Salt = salt
-	Wallet_.CipherType = "aes-256-cbc"
-	return lock()
+	w.keys = walletKeys{}
+	w.key = nil
 }
 
-func (client *Client) LoadWallet(wallet_filename string) bool {
-	if wallet_filename == "" {
-		wallet_filename = WalletName_
+func (w *Wallet) stopTimer() {
+	if w.lockTimer != nil {
+		w.lockTimer.Stop()
+		w.lockTimer = nil
 	}
-	if _, err := os.Stat(wallet_filename); os.IsNotExist(err) {
-		// wallet_filename does not exist
-		return false
+}
+
+// find returns the public key of the key with the given label or public key
+func (w *Wallet) find(name string) (string, error) {
+	if w.key == nil {
+		return "", ErrWalletLocked
 	}
+	if _, ok := w.keys.Keys[name]; ok {
+		return name, nil
+	}
+	for pub, label := range w.keys.Labels {
+		if label == name {
+			return pub, nil
+		}
+	}
+	return "", ErrWalletKeyNotFound
+}
 
-	dat, err := ioutil.ReadFile(wallet_filename)
-	if dat == nil || err != nil {
-		return false
+// update encrypts the changed keys, the wallet must be unlocked
+func (w *Wallet) update() error {
+	return w.file.sealKeys(w.key, w.keys)
+}
+
+//AddKey adds the private key in WIF with an optional label and returns its public key.
+func (w *Wallet) AddKey(label, wifKey string) (string, error) {
+	if _, err := wif.Decode(wifKey); err != nil {
+		return "", err
+	}
+	pub := CreatePublicKey(config.ADDRESS_PREFIX, wifKey)
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.key == nil {
+		return "", ErrWalletLocked
+	}
+	if label != "" {
+		if other, err := w.find(label); err == nil && other != pub {
+			return "", errors.Errorf("The wallet already has a key labeled %q", label)
+		}
 	}
-	err = json.Unmarshal(dat, &Wallet_)
+	w.keys.Keys[pub] = wifKey
+	w.setLabel(pub, label)
+	return pub, w.update()
+}
+
+//AddDerivedKey adds the private key derived at path from the master key, see AddKey.
+func (w *Wallet) AddDerivedKey(label string, master *hdwallet.ExtendedKey, path string) (string, error) {
+	child, err := master.Derive(path)
 	if err != nil {
-		return false
+		return "", err
 	}
-	return true
+	wifKey, err := child.WIF()
+	if err != nil {
+		return "", err
+	}
+	return w.AddKey(label, wifKey)
 }
 
-func saveWallet(wallet_filename string) error {
-	//
-	// Serialize in memory, then save to disk
-	//
-	// This approach lessens the risk of a partially written wallet
-	// if exceptions are thrown in serialization
-	//
-	err := encryptKeys()
+func (w *Wallet) setLabel(pub, label string) {
+	if label == "" {
+		delete(w.keys.Labels, pub)
+		return
+	}
+	if w.keys.Labels == nil {
+		w.keys.Labels = make(map[string]string)
+	}
+	w.keys.Labels[pub] = label
+}
+
+//RemoveKey removes the key with the given label or public key.
+func (w *Wallet) RemoveKey(name string) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	pub, err := w.find(name)
 	if err != nil {
 		return err
 	}
+	delete(w.keys.Keys, pub)
+	delete(w.keys.Labels, pub)
+	return w.update()
+}
 
-	if wallet_filename == "" {
-		wallet_filename = WalletName_
-	}
+//RenameKey sets the label of the key with the given label or public key.
+func (w *Wallet) RenameKey(name, label string) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
 
-	data, err := json.Marshal(Wallet_)
-	if err == nil {
-		f, _ := os.OpenFile(wallet_filename, os.O_TRUNC|os.O_CREATE|os.O_RDWR, 0600)
-		defer f.Close()
-		f.Write(data)
+	pub, err := w.find(name)
+	if err != nil {
+		return err
+	}
+	if label != "" {
+		if other, err := w.find(label); err == nil && other != pub {
+			return errors.Errorf("The wallet already has a key labeled %q", label)
+		}
 	}
-	return err
+	w.setLabel(pub, label)
+	return w.update()
 }
 
-func import_key(wif_key, prefix string) bool {
-	pubKey := CreatePublicKey(prefix, wif_key)
-	Keys_[pubKey] = wif_key
-	return true
+//ListKeys returns the keys of the wallet sorted by label and public key.
+func (w *Wallet) ListKeys() ([]WalletKey, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.key == nil {
+		return nil, ErrWalletLocked
+	}
+	list := make([]WalletKey, 0, len(w.keys.Keys))
+	for pub := range w.keys.Keys {
+		list = append(list, WalletKey{PublicKey: pub, Label: w.keys.Labels[pub]})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Label != list[j].Label {
+			return list[i].Label < list[j].Label
+		}
+		return list[i].PublicKey < list[j].PublicKey
+	})
+	return list, nil
 }
 
-func (client *Client) ImportKey(wif_key, name string) bool {
-	if isLocked() {
-		return false
+//PrivateKey returns the private key in WIF with the given label or public key.
+func (w *Wallet) PrivateKey(name string) (string, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	pub, err := w.find(name)
+	if err != nil {
+		return "", err
 	}
-	Wallet_.Name = name
+	return w.keys.Keys[pub], nil
+}
+
+//Keys returns the keys with the given labels or public keys for Client.SetKeys,
+//all the keys of the wallet without names.
+func (w *Wallet) Keys(names ...string) (*Keys, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
 
-	if import_key(wif_key, config.ADDRESS_PREFIX) {
-		err := saveWallet(name + ".json")
+	if w.key == nil {
+		return nil, ErrWalletLocked
+	}
+	if len(names) == 0 {
+		for pub := range w.keys.Keys {
+			names = append(names, pub)
+		}
+		sort.Strings(names)
+	}
+	keys := &Keys{}
+	for _, name := range names {
+		pub, err := w.find(name)
 		if err != nil {
-			return false
+			return nil, errors.Wrap(err, name)
 		}
-		client.SetKeys(&Keys{OKey: []string{wif_key}})
-		return true
+		keys.OKey = append(keys.OKey, w.keys.Keys[pub])
 	}
-	return false
+	return keys, nil
 }
 
-func encryptKeys() error {
-	var data PlainKeys
-	data.Keys = Keys_
-	copy(data.Checksum[:], Checksum_[:])
-	plainData, err := json.Marshal(data)
-	if err != nil {
+//Save writes the wallet to its file, the file is replaced atomically.
+func (w *Wallet) Save() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.save()
+}
+
+//SaveAs writes the wallet to path and saves it there from now on.
+func (w *Wallet) SaveAs(path string) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.path = path
+	return w.save()
+}
+
+func (w *Wallet) save() error {
+	if w.isNew() {
+		return errors.New("The wallet has no password yet")
+	}
+	return writeWallet(w.path, &w.file)
+}
+
+//Wallet returns the wallet used by LoadWallet, SetPassword, Unlock and ImportKey.
+func (client *Client) Wallet() *Wallet {
+	client.walletMutex.Lock()
+	defer client.walletMutex.Unlock()
+	if client.wallet == nil {
+		client.wallet = NewWallet(DefaultWalletName)
+	}
+	return client.wallet
+}
+
+//SetWallet sets the wallet used by LoadWallet, SetPassword, Unlock and ImportKey.
+func (client *Client) SetWallet(w *Wallet) {
+	client.walletMutex.Lock()
+	defer client.walletMutex.Unlock()
+	client.wallet = w
+}
+
+//Unlock unlocks the wallet of the client and signs with all of its keys.
+func (client *Client) Unlock(password string) error {
+	w := client.Wallet()
+	if err := w.Unlock(password); err != nil {
 		return err
 	}
-	plainTxt := string(plainData)
-	Wallet_.CipherKeys, err = Encrypt(data.Checksum[:], plainTxt)
+	keys, err := w.Keys()
 	if err != nil {
 		return err
 	}
+	client.SetKeys(keys)
 	return nil
 }
 
+func (client *Client) SetPassword(password string) error {
+	return client.Wallet().SetPassword(password)
+}
+
+func (client *Client) LoadWallet(wallet_filename string) bool {
+	w, err := OpenWallet(wallet_filename)
+	if err != nil {
+		return false
+	}
+	client.SetWallet(w)
+	return true
+}
+
+func (client *Client) ImportKey(wif_key, name string) bool {
+	w := client.Wallet()
+	if w.IsLocked() {
+		return false
+	}
+	w.SetName(name)
+
+	if _, err := w.AddKey("", wif_key); err != nil {
+		return false
+	}
+	if err := w.SaveAs(name + ".json"); err != nil {
+		return false
+	}
+	client.SetKeys(&Keys{OKey: []string{wif_key}})
+	return true
+}
+
 func (client *Client) SetKeysFromFileWallet(pathFileWallet string, password string) error {
 	if pathFileWallet == "" {
 		return errors.New("Path file wallet is not empty.")
@@ -244,27 +518,16 @@ func (client *Client) SetKeysFromFileWallet(pathFileWallet string, password stri
 	if data == nil || err != nil {
 		return errors.New("File wallet is empty or can not read.")
 	}
-	var wl *Wallet
-	err = json.Unmarshal(data, &wl)
-	if wl == nil || err != nil {
-		return errors.New("Can not decode json wallet data.")
-	}
-
-	new_password := password + wl.Salt
-	pw := sha512.Sum512([]byte(new_password))
-	decrypted, err := Decrypt(pw[:], wl.CipherKeys)
+	wl, err := parseWalletFile(data)
 	if err != nil {
 		return err
 	}
-	var pk PlainKeys
-	err = json.Unmarshal([]byte(decrypted), &pk)
+
+	keys, _, err := wl.decryptKeys(password)
 	if err != nil {
 		return err
 	}
-	//Set keys
-	for k := range pk.Keys {
-		client.SetKeys(&Keys{OKey: []string{pk.Keys[k]}})
-	}
+	client.SetKeys(keys.signingKeys())
 
 	return nil
 }
@@ -285,43 +548,21 @@ func SaveWalletFile(wallet_path string, wallet_filename string, password string,
 	//
 	// This approach lessens the risk of a partially written wallet
 	// if exceptions are thrown in serialization
-	keys := make(map[string]string)
-	keys[wallet_data.PublicKey] = wallet_data.PrivateKey
-	salt, err := RandStringBytes(16)
-	if err != nil {
-		// Serve an appropriately vague error to the
-		// user, but log the details internally.
-		panic(err)
-	}
-	new_password := password + salt
-	checksum := sha512.Sum512([]byte(new_password))
-
-	var plainKeys PlainKeys
-	plainKeys.Keys = keys
-	copy(plainKeys.Checksum[:], checksum[:])
-	plainData, err := json.Marshal(plainKeys)
+	keys := walletKeys{Keys: map[string]string{wallet_data.PublicKey: wallet_data.PrivateKey}}
+	wl, err := newWalletFile(wallet_data.Name, password, keys)
 	if err != nil {
 		return err
 	}
-	plainTxt := string(plainData)
-	cipherKeys, err := Encrypt(plainKeys.Checksum[:], plainTxt)
 
 	if wallet_filename == "" {
-		wallet_filename = wallet_data.Name + "-" + WalletName_
+		wallet_filename = wallet_data.Name + "-" + DefaultWalletName
 	}
 	file_path := wallet_filename
 	if wallet_path != "" {
 		file_path = wallet_path + string(os.PathSeparator) + wallet_filename
 	}
 
-	wl := Wallet{CipherKeys: cipherKeys, CipherType: "aes-256-cbc", Name: wallet_data.Name, Salt: salt}
-	data, err := json.Marshal(wl)
-	if err == nil {
-		f, _ := os.OpenFile(file_path, os.O_TRUNC|os.O_CREATE|os.O_RDWR, 0600)
-		defer f.Close()
-		f.Write(data)
-	}
-	return err
+	return writeWallet(file_path, wl)
 }
 
 func EncodeWallet(password string, wallet_data *WalletData) (string, error) {
@@ -340,28 +581,12 @@ func EncodeWallet(password string, wallet_data *WalletData) (string, error) {
 	//
 	// This approach lessens the risk of a partially written wallet
 	// if exceptions are thrown in serialization
-	keys := make(map[string]string)
-	keys[wallet_data.PublicKey] = wallet_data.PrivateKey
-	salt, err := RandStringBytes(16)
-	if err != nil {
-		// Serve an appropriately vague error to the
-		// user, but log the details internally.
-		panic(err)
-	}
-	new_password := password + salt
-	checksum := sha512.Sum512([]byte(new_password))
-
-	var plainKeys PlainKeys
-	plainKeys.Keys = keys
-	copy(plainKeys.Checksum[:], checksum[:])
-	plainData, err := json.Marshal(plainKeys)
+	keys := walletKeys{Keys: map[string]string{wallet_data.PublicKey: wallet_data.PrivateKey}}
+	wl, err := newWalletFile(wallet_data.Name, password, keys)
 	if err != nil {
 		return "", err
 	}
-	plainTxt := string(plainData)
-	cipherKeys, err := Encrypt(plainKeys.Checksum[:], plainTxt)
 
-	wl := Wallet{CipherKeys: cipherKeys, CipherType: "aes-256-cbc", Name: wallet_data.Name, Salt: salt}
 	data, err := json.Marshal(wl)
 	if err != nil {
 		return "", err
@@ -377,27 +602,16 @@ func (client *Client) SetKeysFromEncodeWallet(wallet_json string, password strin
 		return errors.New("Password is not empty.")
 	}
 
-	var wl *Wallet
-	err := json.Unmarshal([]byte(wallet_json), &wl)
-	if wl == nil || err != nil {
-		return errors.New("Can not decode json wallet data.")
-	}
-
-	new_password := password + wl.Salt
-	pw := sha512.Sum512([]byte(new_password))
-	decrypted, err := Decrypt(pw[:], wl.CipherKeys)
+	wl, err := parseWalletFile([]byte(wallet_json))
 	if err != nil {
 		return err
 	}
-	var pk PlainKeys
-	err = json.Unmarshal([]byte(decrypted), &pk)
+
+	keys, _, err := wl.decryptKeys(password)
 	if err != nil {
 		return err
 	}
-	//Set keys
-	for k := range pk.Keys {
-		client.SetKeys(&Keys{OKey: []string{pk.Keys[k]}})
-	}
+	client.SetKeys(keys.signingKeys())
 
 	return nil
 }
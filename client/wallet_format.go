@@ -0,0 +1,339 @@
+package client
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	//WalletVersionLegacy is the format of the wallets without a version field:
+	//the key is the sha512 of password+salt and the keys are encrypted with aes-256-cbc.
+	WalletVersionLegacy = 1
+	//WalletVersion is the format of the new wallets: the key is derived with a KDF
+	//and the keys are encrypted with an AEAD cipher.
+	WalletVersion = 2
+
+	CipherAES256CBC = "aes-256-cbc"
+	CipherAES256GCM = "aes-256-gcm"
+
+	KdfScrypt = "scrypt"
+
+	walletSaltSize = 16
+	walletKeySize  = 32
+)
+
+//KdfParams describes how the encryption key of a wallet is derived from its password
+type KdfParams struct {
+	Name string `json:"name"`
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+}
+
+//WalletKdf is used for the wallets created or migrated from now on, raise N to make
+//password guessing more expensive at the cost of a slower unlock.
+var WalletKdf = KdfParams{Name: KdfScrypt, N: 1 << 15, R: 8, P: 1}
+
+// walletKeys is the plaintext of a version 2 wallet, both maps are keyed by the public key
+type walletKeys struct {
+	Keys   map[string]string `json:"keys"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// signingKeys returns all the private keys, sorted by public key like Wallet.Keys
+func (wk walletKeys) signingKeys() *Keys {
+	pubs := make([]string, 0, len(wk.Keys))
+	for pub := range wk.Keys {
+		pubs = append(pubs, pub)
+	}
+	sort.Strings(pubs)
+	keys := &Keys{}
+	for _, pub := range pubs {
+		keys.OKey = append(keys.OKey, wk.Keys[pub])
+	}
+	return keys
+}
+
+func (kdf *KdfParams) deriveKey(password string, salt []byte) ([]byte, error) {
+	switch kdf.Name {
+	case KdfScrypt:
+		return scrypt.Key([]byte(password), salt, kdf.N, kdf.R, kdf.P, walletKeySize)
+	default:
+		return nil, errors.Errorf("unsupported wallet kdf %q", kdf.Name)
+	}
+}
+
+func (wl *WalletFile) version() int {
+	if wl.Version == 0 {
+		return WalletVersionLegacy
+	}
+	return wl.Version
+}
+
+// check makes sure the wallet is in a format this package can read
+func (wl *WalletFile) check() error {
+	switch wl.version() {
+	case WalletVersionLegacy:
+		if wl.CipherType != "" && wl.CipherType != CipherAES256CBC {
+			return errors.Errorf("unsupported cipher %q for wallet version %d", wl.CipherType, WalletVersionLegacy)
+		}
+	case WalletVersion:
+		if wl.CipherType != CipherAES256GCM {
+			return errors.Errorf("unsupported cipher %q for wallet version %d", wl.CipherType, WalletVersion)
+		}
+		if wl.Kdf == nil {
+			return errors.New("wallet has no kdf parameters")
+		}
+	default:
+		return errors.Errorf("unsupported wallet version %d", wl.Version)
+	}
+	return nil
+}
+
+// key returns the encryption key of the wallet for password, for a legacy wallet it is
+// the sha512 checksum of password+salt
+func (wl *WalletFile) key(password string) ([]byte, error) {
+	if err := wl.check(); err != nil {
+		return nil, err
+	}
+	if wl.version() == WalletVersionLegacy {
+		checksum := sha512.Sum512([]byte(password + wl.Salt))
+		return checksum[:], nil
+	}
+	salt, err := hex.DecodeString(wl.Salt)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid wallet salt")
+	}
+	return wl.Kdf.deriveKey(password, salt)
+}
+
+// additionalData binds the header of a version 2 wallet to its ciphertext
+func (wl *WalletFile) additionalData() []byte {
+	data, _ := json.Marshal(struct {
+		Version    int        `json:"version"`
+		CipherType string     `json:"cipher_type"`
+		Salt       string     `json:"salt"`
+		Kdf        *KdfParams `json:"kdf"`
+	}{wl.Version, wl.CipherType, wl.Salt, wl.Kdf})
+	return data
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key[:walletKeySize])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// sealKeys encrypts the keys with the key of the wallet, a legacy wallet has no labels
+func (wl *WalletFile) sealKeys(key []byte, keys walletKeys) error {
+	if wl.version() == WalletVersionLegacy {
+		var pk PlainKeys
+		pk.Keys = keys.Keys
+		copy(pk.Checksum[:], key)
+		plainData, err := json.Marshal(pk)
+		if err != nil {
+			return err
+		}
+		wl.CipherKeys, err = Encrypt(pk.Checksum[:], string(plainData))
+		return err
+	}
+
+	plainData, err := json.Marshal(keys)
+	if err != nil {
+		return err
+	}
+	aead, err := newGCM(key)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+	sealed := aead.Seal(nonce, nonce, plainData, wl.additionalData())
+	wl.CipherKeys = base64.StdEncoding.EncodeToString(sealed)
+	return nil
+}
+
+// openKeys decrypts the keys with the key of the wallet
+func (wl *WalletFile) openKeys(key []byte) (walletKeys, error) {
+	var wk walletKeys
+	if wl.version() == WalletVersionLegacy {
+		decrypted, err := Decrypt(key, wl.CipherKeys)
+		if err != nil {
+			return wk, err
+		}
+		var pk PlainKeys
+		if err := json.Unmarshal([]byte(decrypted), &pk); err != nil {
+			return wk, err
+		}
+		if string(pk.Checksum[:]) != string(key) {
+			return wk, errors.New("Don't match checksum")
+		}
+		wk.Keys = pk.Keys
+		return wk, nil
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(wl.CipherKeys)
+	if err != nil {
+		return wk, errors.Wrap(err, "invalid wallet cipher keys")
+	}
+	aead, err := newGCM(key)
+	if err != nil {
+		return wk, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return wk, errors.New("wallet cipher keys are too short")
+	}
+	plainData, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], wl.additionalData())
+	if err != nil {
+		return wk, errors.New("wrong password or corrupted wallet")
+	}
+	err = json.Unmarshal(plainData, &wk)
+	return wk, err
+}
+
+// decryptKeys returns the keys of the wallet and the encryption key derived from password
+func (wl *WalletFile) decryptKeys(password string) (walletKeys, []byte, error) {
+	key, err := wl.key(password)
+	if err != nil {
+		return walletKeys{}, nil, err
+	}
+	keys, err := wl.openKeys(key)
+	if err != nil {
+		return walletKeys{}, nil, err
+	}
+	return keys, key, nil
+}
+
+// init sets up wl as an empty version 2 wallet with a new salt and returns its key
+func (wl *WalletFile) init(password string) ([]byte, error) {
+	salt := make([]byte, walletSaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	kdf := WalletKdf
+	wl.Version = WalletVersion
+	wl.CipherType = CipherAES256GCM
+	wl.Salt = hex.EncodeToString(salt)
+	wl.Kdf = &kdf
+	wl.CipherKeys = ""
+	return wl.key(password)
+}
+
+// newWalletFile creates a version 2 wallet holding keys
+func newWalletFile(name, password string, keys walletKeys) (*WalletFile, error) {
+	wl := &WalletFile{Name: name}
+	key, err := wl.init(password)
+	if err != nil {
+		return nil, err
+	}
+	if err := wl.sealKeys(key, keys); err != nil {
+		return nil, err
+	}
+	return wl, nil
+}
+
+func parseWalletFile(data []byte) (*WalletFile, error) {
+	var wl *WalletFile
+	if err := json.Unmarshal(data, &wl); wl == nil || err != nil {
+		return nil, errors.New("Can not decode json wallet data.")
+	}
+	if err := wl.check(); err != nil {
+		return nil, err
+	}
+	return wl, nil
+}
+
+// writeWalletFile replaces the file at path atomically: the data is written and synced
+// to a temporary file in the same directory that is then renamed over path
+func writeWalletFile(path string, data []byte) error {
+	dir, base := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+	tmp, err := ioutil.TempFile(dir, base+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "failed to create the wallet file")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "failed to write the wallet file")
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "failed to write the wallet file")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "failed to write the wallet file")
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return errors.Wrap(err, "failed to replace the wallet file")
+	}
+	// make the rename durable, not every platform can sync a directory
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+	return nil
+}
+
+func writeWallet(path string, wl *WalletFile) error {
+	data, err := json.Marshal(wl)
+	if err != nil {
+		return err
+	}
+	return writeWalletFile(path, data)
+}
+
+//MigrateWallet re-encrypts the wallet json in the current format with WalletKdf,
+//a wallet already in the current format gets a new salt and nonce.
+func MigrateWallet(wallet_json string, password string) (string, error) {
+	wl, err := parseWalletFile([]byte(wallet_json))
+	if err != nil {
+		return "", err
+	}
+	keys, _, err := wl.decryptKeys(password)
+	if err != nil {
+		return "", err
+	}
+	migrated, err := newWalletFile(wl.Name, password, keys)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(migrated)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+//MigrateWalletFile rewrites the wallet file in the current format, see MigrateWallet.
+func MigrateWalletFile(pathFileWallet string, password string) error {
+	data, err := ioutil.ReadFile(pathFileWallet)
+	if err != nil {
+		return err
+	}
+	migrated, err := MigrateWallet(string(data), password)
+	if err != nil {
+		return err
+	}
+	return writeWalletFile(pathFileWallet, []byte(migrated))
+}
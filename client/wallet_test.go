@@ -0,0 +1,187 @@
+package client
+
+import (
+	"crypto/sha512"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func legacyWalletJSON(t *testing.T, password string, keys walletKeys) string {
+	wl := &WalletFile{CipherType: CipherAES256CBC, Salt: "0123456789abcdef", Name: "alice"}
+	checksum := sha512.Sum512([]byte(password + wl.Salt))
+	if err := wl.sealKeys(checksum[:], keys); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(wl)
+	return string(data)
+}
+
+func TestWalletFormats(t *testing.T) {
+	defer func(kdf KdfParams) { WalletKdf = kdf }(WalletKdf)
+	WalletKdf.N = 1 << 10
+
+	const password = "correct horse"
+	keys := walletKeys{Keys: map[string]string{"BEO1": "5Jwif"}}
+
+	encoded, err := EncodeWallet(password, &WalletData{Name: "alice", PublicKey: "BEO1", PrivateKey: "5Jwif"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wl, err := parseWalletFile([]byte(encoded))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wl.Version != WalletVersion || wl.CipherType != CipherAES256GCM || wl.Kdf.N != 1<<10 {
+		t.Fatalf("unexpected wallet header %+v", wl)
+	}
+	if got, _, err := wl.decryptKeys(password); err != nil || got.Keys["BEO1"] != "5Jwif" {
+		t.Fatalf("decryptKeys = %v, %v", got, err)
+	}
+	if _, _, err := wl.decryptKeys("wrong password"); err == nil {
+		t.Error("wrong password accepted")
+	}
+
+	// the header is authenticated
+	tampered := *wl
+	tampered.Kdf = &KdfParams{Name: KdfScrypt, N: 1 << 10, R: 8, P: 2}
+	if _, _, err := tampered.decryptKeys(password); err == nil {
+		t.Error("tampered header accepted")
+	}
+
+	legacy := legacyWalletJSON(t, password, keys)
+	migrated, err := MigrateWallet(legacy, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wl, err = parseWalletFile([]byte(migrated))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wl.Version != WalletVersion || wl.Name != "alice" {
+		t.Fatalf("unexpected migrated wallet %+v", wl)
+	}
+	if got, _, err := wl.decryptKeys(password); err != nil || got.Keys["BEO1"] != "5Jwif" {
+		t.Fatalf("migrated decryptKeys = %v, %v", got, err)
+	}
+
+	if _, err := parseWalletFile([]byte(strings.Replace(migrated, `"version":2`, `"version":3`, 1))); err == nil {
+		t.Error("unknown version accepted")
+	}
+
+	// every key of the wallet is set, not only the last one read
+	cls := &Client{}
+	legacy = legacyWalletJSON(t, password, walletKeys{Keys: map[string]string{"BEO2": "5Kwif", "BEO1": "5Jwif"}})
+	if err := cls.SetKeysFromEncodeWallet(legacy, password); err != nil {
+		t.Fatal(err)
+	}
+	if okeys := cls.CurrentKeys.OKey; len(okeys) != 2 || okeys[0] != "5Jwif" || okeys[1] != "5Kwif" {
+		t.Errorf("keys %v", okeys)
+	}
+}
+
+func TestWallet(t *testing.T) {
+	defer func(kdf KdfParams) { WalletKdf = kdf }(WalletKdf)
+	WalletKdf.N = 1 << 10
+
+	const password = "correct horse"
+	const wif1 = "5JWHY5DxTF6qN5grTtChDCYBmWHfY9zaSsw4CxEKN5eZpH9iBma"
+	const wif2 = "5KPipdRzoxrp6dDqsBfMD6oFZG356trVHV5QBGx3rABs1zzWWs8"
+	path := filepath.Join(t.TempDir(), "wallet.json")
+
+	w := NewWallet(path)
+	if err := w.SetPassword(password); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AddKey("owner", wif1); err != ErrWalletLocked {
+		t.Fatalf("AddKey on a locked wallet: %v", err)
+	}
+	if err := w.Unlock(password); err != nil {
+		t.Fatal(err)
+	}
+	pub1, err := w.AddKey("owner", wif1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AddKey("owner", wif2); err == nil {
+		t.Error("duplicate label accepted")
+	}
+	if _, err := w.AddKey("backup", wif2); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.RenameKey("backup", "spare"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	opened, err := OpenWallet(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !opened.IsLocked() {
+		t.Fatal("opened wallet is unlocked")
+	}
+	opened.SetLockTimeout(50 * time.Millisecond)
+	if err := opened.Unlock(password); err != nil {
+		t.Fatal(err)
+	}
+	list, err := opened.ListKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[0].Label != "owner" || list[0].PublicKey != pub1 || list[1].Label != "spare" {
+		t.Fatalf("unexpected keys %+v", list)
+	}
+	if key, err := opened.PrivateKey("spare"); err != nil || key != wif2 {
+		t.Errorf("PrivateKey = %q, %v", key, err)
+	}
+	if err := opened.RemoveKey(pub1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := opened.PrivateKey("owner"); err != ErrWalletKeyNotFound {
+		t.Errorf("removed key: %v", err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	if !opened.IsLocked() {
+		t.Error("wallet not locked after the timeout")
+	}
+
+	// the timer fires while the wallet is being unlocked again
+	if err := opened.Unlock(password); err != nil {
+		t.Fatal(err)
+	}
+	opened.mutex.Lock()
+	time.Sleep(100 * time.Millisecond)
+	opened.lockTimeout = time.Hour
+	err = opened.unlock(password)
+	opened.mutex.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if opened.IsLocked() {
+		t.Error("wallet locked by the timer of an earlier unlock")
+	}
+	opened.Lock()
+}
+
+func TestClientWalletConcurrent(t *testing.T) {
+	client := &Client{}
+	wallets := make(chan *Wallet, 8)
+	for i := 0; i < cap(wallets); i++ {
+		go func() {
+			wallets <- client.Wallet()
+		}()
+	}
+	first := <-wallets
+	for i := 1; i < cap(wallets); i++ {
+		if w := <-wallets; w != first {
+			t.Fatal("concurrent calls created several wallets")
+		}
+	}
+}
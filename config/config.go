@@ -3,6 +3,10 @@ package config
 // AddressPrefix
 const ADDRESS_PREFIX string = "BEO"
 const HTTP_CONNECTION_TIMEOUT_SECOND = 60
+const HTTP_MAX_IDLE_CONNS = 100
+const HTTP_MAX_IDLE_CONNS_PER_HOST = 100
+const HTTP_IDLE_CONN_TIMEOUT_SECOND = 90
+const RPC_BATCH_MAX_SIZE = 50
 
 // ChainId
 const CHAIN_ID_MAINNET string = "e2222eeabcf9224632c82ec86ba3d77b359e3b5cb8a089ddd45090c31c98e3f2"
@@ -14,12 +18,23 @@ const HEAD_BLOCK_NUM_SPAN = 50
 
 const TRANSACTION_EXPIRATION_IN_MIN = 55 //10
 
+const REBROADCAST_DELAY_SECOND = 3
+
 const SMT_CREATION_FEE = "1.00000 W"
 
 const WD_SYMBOL = "W"
 
+const WD_PRECISION = 5
+
 const MIN_TRANSACTION_FEE = 0.01000
 
 const MIN_ACCOUNT_CREATION_FEE = 0.01000
 
 const NAME_LETTER = "0123456789abcdefghijklmnopqrstuvwxyz-"
+
+const MAX_SIG_CHECK_DEPTH = 2
+
+// Sidechain
+const SIDECHAIN_ID = "s01"
+const SIDECHAIN_FIND_PAGE_SIZE = 1000
+const NFT_HOLDINGS_CONCURRENCY = 4
@@ -0,0 +1,265 @@
+package transaction
+
+import (
+	// Stdlib
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	// Vendor
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/pkg/errors"
+	"github.com/thanhxeon2470/beowulf-go/config"
+	"golang.org/x/crypto/ripemd160"
+)
+
+const (
+	pubKeyLength     = 33
+	symbolNameLength = 9
+)
+
+//Decoder structure for the reverse converter
+type Decoder struct {
+	r *bufio.Reader
+}
+
+//NewDecoder initializing a new reverse converter
+func NewDecoder(r io.Reader) *Decoder {
+	if br, ok := r.(*bufio.Reader); ok {
+		return &Decoder{br}
+	}
+	return &Decoder{bufio.NewReader(r)}
+}
+
+//EOF reports whether there is no more data to decode
+func (decoder *Decoder) EOF() bool {
+	_, err := decoder.r.Peek(1)
+	return err == io.EOF
+}
+
+//DecodeVarint converting byte to int64
+func (decoder *Decoder) DecodeVarint() (int64, error) {
+	// Encoder writes non-negative values as uvarint, which is what the chain expects.
+	i, err := decoder.DecodeUVarint()
+	return int64(i), err
+}
+
+//DecodeUVarint converting byte to uint64
+func (decoder *Decoder) DecodeUVarint() (uint64, error) {
+	i, err := binary.ReadUvarint(decoder.r)
+	if err != nil {
+		return 0, errors.Wrap(err, "decoder: failed to read uvarint")
+	}
+	return i, nil
+}
+
+//PeekUVarint returns the next uint64 without consuming it
+func (decoder *Decoder) PeekUVarint() (uint64, error) {
+	b, err := decoder.r.Peek(binary.MaxVarintLen64)
+	if err != nil && len(b) == 0 {
+		return 0, errors.Wrap(err, "decoder: failed to peek uvarint")
+	}
+	i, n := binary.Uvarint(b)
+	if n <= 0 {
+		return 0, errors.New("decoder: invalid uvarint")
+	}
+	return i, nil
+}
+
+//DecodeNumber converting byte to number, v must be a pointer to a fixed-size number
+func (decoder *Decoder) DecodeNumber(v interface{}) error {
+	if err := binary.Read(decoder.r, binary.LittleEndian, v); err != nil {
+		return errors.Wrapf(err, "decoder: failed to read number: %T", v)
+	}
+	return nil
+}
+
+//DecodeArrString converting byte to []string
+func (decoder *Decoder) DecodeArrString() ([]string, error) {
+	n, err := decoder.DecodeUVarint()
+	if err != nil {
+		return nil, errors.Wrap(err, "decoder: failed to read string array length")
+	}
+	v := make([]string, 0, n)
+	for i := uint64(0); i < n; i++ {
+		s, err := decoder.DecodeString()
+		if err != nil {
+			return nil, err
+		}
+		v = append(v, s)
+	}
+	return v, nil
+}
+
+//Decode function that determines the output values of which reverse converter to use
+func (decoder *Decoder) Decode(v interface{}) error {
+	if unmarshaller, ok := v.(TransactionUnmarshaller); ok {
+		return unmarshaller.UnmarshalTransaction(decoder)
+	}
+
+	switch v := v.(type) {
+	case *int8, *int16, *int32, *int64,
+		*uint8, *uint16, *uint32, *uint64:
+		return decoder.DecodeNumber(v)
+
+	case *string:
+		s, err := decoder.DecodeString()
+		if err != nil {
+			return err
+		}
+		*v = s
+		return nil
+	case []byte:
+		return decoder.readBytes(v)
+	default:
+		return errors.Errorf("decoder: unsupported type encountered")
+	}
+}
+
+//DecodeString converting byte to string
+func (decoder *Decoder) DecodeString() (string, error) {
+	n, err := decoder.DecodeUVarint()
+	if err != nil {
+		return "", errors.Wrap(err, "decoder: failed to read string length")
+	}
+
+	b := make([]byte, n)
+	if err := decoder.readBytes(b); err != nil {
+		return "", errors.Wrap(err, "decoder: failed to read string")
+	}
+	return string(b), nil
+}
+
+func (decoder *Decoder) readBytes(bs []byte) error {
+	if _, err := io.ReadFull(decoder.r, bs); err != nil {
+		return errors.Wrapf(err, "decoder: failed to read %d bytes", len(bs))
+	}
+	return nil
+}
+
+//DecodeBool converting byte to bool
+func (decoder *Decoder) DecodeBool() (bool, error) {
+	var b byte
+	if err := decoder.DecodeNumber(&b); err != nil {
+		return false, err
+	}
+	return b != 0, nil
+}
+
+//DecodeMoney converting byte to Asset string like '99.00000 SYMBOL'
+func (decoder *Decoder) DecodeMoney() (string, error) {
+	amm, perc, name, err := decoder.DecodeAsset()
+	if err != nil {
+		return "", err
+	}
+	return formatAmount(amm, uint32(perc)) + " " + name, nil
+}
+
+//DecodeAsset converting byte to the integer amount, precision and symbol of an Asset
+func (decoder *Decoder) DecodeAsset() (int64, uint8, string, error) {
+	var amm int64
+	if err := decoder.DecodeNumber(&amm); err != nil {
+		return 0, 0, "", err
+	}
+	var perc uint32
+	if err := decoder.DecodeNumber(&perc); err != nil {
+		return 0, 0, "", err
+	}
+	if perc > 18 {
+		return 0, 0, "", errors.Errorf("decoder: invalid asset precision: %d", perc)
+	}
+	name, err := decoder.decodeSymbolName()
+	if err != nil {
+		return 0, 0, "", err
+	}
+	return amm, uint8(perc), name, nil
+}
+
+func formatAmount(amm int64, perc uint32) string {
+	s := strconv.FormatInt(amm, 10)
+	if perc == 0 {
+		return s
+	}
+
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	if len(s) <= int(perc) {
+		s = strings.Repeat("0", int(perc)-len(s)+1) + s
+	}
+	s = s[:len(s)-int(perc)] + "." + s[len(s)-int(perc):]
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
+
+func (decoder *Decoder) decodeSymbolName() (string, error) {
+	b := make([]byte, symbolNameLength)
+	if err := decoder.readBytes(b); err != nil {
+		return "", errors.Wrap(err, "decoder: failed to read symbol name")
+	}
+	return string(bytes.TrimRight(b, "\x00")), nil
+}
+
+//DecodePubKey converting byte to PubKey string with the configured address prefix
+func (decoder *Decoder) DecodePubKey() (string, error) {
+	b := make([]byte, pubKeyLength)
+	if err := decoder.readBytes(b); err != nil {
+		return "", errors.Wrap(err, "decoder: failed to read public key")
+	}
+	if !bytes.Equal(b, make([]byte, pubKeyLength)) {
+		if _, err := btcec.ParsePubKey(b, btcec.S256()); err != nil {
+			return "", errors.Wrap(err, "decoder: public key is incorrect")
+		}
+	}
+
+	chHash := ripemd160.New()
+	if _, err := chHash.Write(b); err != nil {
+		return "", err
+	}
+	chs := chHash.Sum(nil)[:4]
+	return config.ADDRESS_PREFIX + base58.Encode(append(b, chs...)), nil
+}
+
+//DecodeSymbol converting byte to Symbol JSON like '{"decimals":5,"name":"W"}'
+func (decoder *Decoder) DecodeSymbol() (string, error) {
+	type symbol struct {
+		Decimals  uint8  `json:"decimals"`
+		AssetName string `json:"name"`
+	}
+	var decimals uint32
+	if err := decoder.DecodeNumber(&decimals); err != nil {
+		return "", err
+	}
+	name, err := decoder.decodeSymbolName()
+	if err != nil {
+		return "", err
+	}
+
+	ans, err := json.Marshal(symbol{Decimals: uint8(decimals), AssetName: name})
+	if err != nil {
+		return "", err
+	}
+	return string(ans), nil
+}
+
+//DecodeExt converting byte to extension JSON like '{"data":"..."}'
+func (decoder *Decoder) DecodeExt() (string, error) {
+	type extensionjsontype struct {
+		Data string `json:"data"`
+	}
+	data, err := decoder.DecodeString()
+	if err != nil {
+		return "", err
+	}
+
+	ans, err := json.Marshal(extensionjsontype{Data: data})
+	if err != nil {
+		return "", err
+	}
+	return string(ans), nil
+}
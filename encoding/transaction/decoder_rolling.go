@@ -0,0 +1,94 @@
+package transaction
+
+//RollingDecoder structure for the chain of reverse converters
+type RollingDecoder struct {
+	next *Decoder
+	err  error
+}
+
+//NewRollingDecoder initializing the chain of reverse converters
+func NewRollingDecoder(next *Decoder) *RollingDecoder {
+	return &RollingDecoder{next, nil}
+}
+
+//DecodeVarint reading int64 from the converted value
+func (decoder *RollingDecoder) DecodeVarint(v *int64) {
+	if decoder.err == nil {
+		*v, decoder.err = decoder.next.DecodeVarint()
+	}
+}
+
+//DecodeUVarint reading uint64 from the converted value
+func (decoder *RollingDecoder) DecodeUVarint(v *uint64) {
+	if decoder.err == nil {
+		*v, decoder.err = decoder.next.DecodeUVarint()
+	}
+}
+
+//DecodeNumber reading number from the converted value
+func (decoder *RollingDecoder) DecodeNumber(v interface{}) {
+	if decoder.err == nil {
+		decoder.err = decoder.next.DecodeNumber(v)
+	}
+}
+
+//DecodeBool reading bool from the converted value
+func (decoder *RollingDecoder) DecodeBool(v *bool) {
+	if decoder.err == nil {
+		*v, decoder.err = decoder.next.DecodeBool()
+	}
+}
+
+//DecodeMoney reading Asset from the converted value
+func (decoder *RollingDecoder) DecodeMoney(v *string) {
+	if decoder.err == nil {
+		*v, decoder.err = decoder.next.DecodeMoney()
+	}
+}
+
+//DecodeSymbol reading Symbol from the converted value
+func (decoder *RollingDecoder) DecodeSymbol(v *string) {
+	if decoder.err == nil {
+		*v, decoder.err = decoder.next.DecodeSymbol()
+	}
+}
+
+//DecodeExt reading extension from the converted value
+func (decoder *RollingDecoder) DecodeExt(v *string) {
+	if decoder.err == nil {
+		*v, decoder.err = decoder.next.DecodeExt()
+	}
+}
+
+//DecodeString reading string from the converted value
+func (decoder *RollingDecoder) DecodeString(v *string) {
+	if decoder.err == nil {
+		*v, decoder.err = decoder.next.DecodeString()
+	}
+}
+
+//DecodePubKey reading PubKey from the converted value
+func (decoder *RollingDecoder) DecodePubKey(v *string) {
+	if decoder.err == nil {
+		*v, decoder.err = decoder.next.DecodePubKey()
+	}
+}
+
+//DecodeArrString reading []string from the converted value
+func (decoder *RollingDecoder) DecodeArrString(v *[]string) {
+	if decoder.err == nil {
+		*v, decoder.err = decoder.next.DecodeArrString()
+	}
+}
+
+//Decode reading from a chain of other values
+func (decoder *RollingDecoder) Decode(v interface{}) {
+	if decoder.err == nil {
+		decoder.err = decoder.next.Decode(v)
+	}
+}
+
+//Err function that returns an error (if any) from the chain of reverse converters
+func (decoder *RollingDecoder) Err() error {
+	return decoder.err
+}
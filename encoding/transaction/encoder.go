@@ -147,31 +147,36 @@ func (encoder *Encoder) EncodeMoney(s string) error {
 			return errParsInt
 		}
 		ind := strings.Index(asset[0], ".")
-		var perc uint32
+		var perc uint8
 		if ind == -1 {
 			perc = 0
 		} else {
-			perc = uint32(len(asset[0]) - ind - 1)
-		}
-		if err := binary.Write(encoder.w, binary.LittleEndian, amm); err != nil {
-			return errors.Wrapf(err, "encoder: failed to write number: %v", amm)
-		}
-		if err := binary.Write(encoder.w, binary.LittleEndian, perc); err != nil {
-			return errors.Wrapf(err, "encoder: failed to write number: %v", perc)
+			perc = uint8(len(asset[0]) - ind - 1)
 		}
+		return encoder.EncodeAsset(amm, perc, asset[1])
+	}
+	return errors.New("Expecting amount like '99.00000 SYMBOL'")
+}
 
-		if _, err := io.Copy(encoder.w, strings.NewReader(asset[1])); err != nil {
-			return errors.Wrapf(err, "encoder: failed to write string: %v", asset[1])
-		}
+//EncodeAsset converting the integer amount, precision and symbol of an Asset to byte
+func (encoder *Encoder) EncodeAsset(amount int64, precision uint8, symbol string) error {
+	if err := binary.Write(encoder.w, binary.LittleEndian, amount); err != nil {
+		return errors.Wrapf(err, "encoder: failed to write number: %v", amount)
+	}
+	if err := binary.Write(encoder.w, binary.LittleEndian, uint32(precision)); err != nil {
+		return errors.Wrapf(err, "encoder: failed to write number: %v", precision)
+	}
 
-		for i := byte(len(asset[1])); i < 9; i++ {
-			if err := binary.Write(encoder.w, binary.LittleEndian, byte(0)); err != nil {
-				return errors.Wrapf(err, "encoder: failed to write number: %v", 0)
-			}
+	if _, err := io.Copy(encoder.w, strings.NewReader(symbol)); err != nil {
+		return errors.Wrapf(err, "encoder: failed to write string: %v", symbol)
+	}
+
+	for i := len(symbol); i < 9; i++ {
+		if err := binary.Write(encoder.w, binary.LittleEndian, byte(0)); err != nil {
+			return errors.Wrapf(err, "encoder: failed to write number: %v", 0)
 		}
-		return nil
 	}
-	return errors.New("Expecting amount like '99.00000 SYMBOL'")
+	return nil
 }
 
 //EncodePubKey converting PubKey to byte
@@ -4,3 +4,8 @@ package transaction
 type TransactionMarshaller interface {
 	MarshalTransaction(*Encoder) error
 }
+
+//TransactionUnmarshaller interface for converting byte back into data
+type TransactionUnmarshaller interface {
+	UnmarshalTransaction(*Decoder) error
+}
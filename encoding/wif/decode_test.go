@@ -37,3 +37,17 @@ func TestDecode(t *testing.T) {
 		}
 	}
 }
+
+func TestEncode(t *testing.T) {
+	for _, d := range data {
+		privKey, _ := hex.DecodeString(d.PrivateKeyHex)
+		got, err := Encode(privKey)
+		if err != nil {
+			t.Error(err)
+		}
+
+		if got != d.WIF {
+			t.Errorf("expected %v, got %v", d.WIF, got)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package wif
+
+import (
+	// Vendor
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil"
+	"github.com/pkg/errors"
+)
+
+// Encode turns a raw private key (32 bytes) into WIF.
+func Encode(privKey []byte) (string, error) {
+	if len(privKey) != btcec.PrivKeyBytesLen {
+		return "", errors.Errorf("private key must be %d bytes, got %d", btcec.PrivKeyBytesLen, len(privKey))
+	}
+	priv, _ := btcec.PrivKeyFromBytes(btcec.S256(), privKey)
+	w, err := btcutil.NewWIF(priv, &chaincfg.MainNetParams, false)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to encode WIF")
+	}
+	return w.String(), nil
+}
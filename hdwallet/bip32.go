@@ -0,0 +1,384 @@
+package hdwallet
+
+import (
+	// Stdlib
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	// RPC
+	"github.com/thanhxeon2470/beowulf-go/encoding/wif"
+	"github.com/thanhxeon2470/beowulf-go/transactions"
+
+	// Vendor
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ripemd160"
+)
+
+//HardenedKeyStart is the index of the first hardened child key, written i' or iH in a path.
+const HardenedKeyStart uint32 = 0x80000000
+
+const (
+	minSeedLen = 16
+	maxSeedLen = 64
+
+	serializedKeyLen = 78
+)
+
+var (
+	// the xprv and xpub versions of BIP-32, kept for compatibility with other tools
+	versionPrivate = []byte{0x04, 0x88, 0xad, 0xe4}
+	versionPublic  = []byte{0x04, 0x88, 0xb2, 0x1e}
+
+	masterKey = []byte("Bitcoin seed")
+)
+
+var (
+	ErrInvalidSeed    = errors.New("seed must be 16 to 64 bytes")
+	ErrInvalidChild   = errors.New("the child key is invalid, use the next index")
+	ErrHardenedPublic = errors.New("a hardened child cannot be derived from a public key")
+	ErrNotPrivate     = errors.New("the extended key has no private key")
+	ErrInvalidPath    = errors.New("invalid derivation path")
+	ErrInvalidExtKey  = errors.New("invalid extended key")
+	ErrExtKeyChecksum = errors.New("extended key checksum mismatch")
+)
+
+//ExtendedKey is a BIP-32 private or public key with its chain code
+type ExtendedKey struct {
+	// 32-byte private key or 33-byte compressed public key
+	key       []byte
+	chainCode []byte
+	depth     uint8
+	parentFP  []byte
+	index     uint32
+	private   bool
+}
+
+//NewMaster returns the master key of the seed, e.g. of NewSeed.
+func NewMaster(seed []byte) (*ExtendedKey, error) {
+	if len(seed) < minSeedLen || len(seed) > maxSeedLen {
+		return nil, ErrInvalidSeed
+	}
+	mac := hmac.New(sha512.New, masterKey)
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	k := new(big.Int).SetBytes(sum[:32])
+	if k.Sign() == 0 || k.Cmp(btcec.S256().N) >= 0 {
+		return nil, ErrInvalidSeed
+	}
+	return &ExtendedKey{
+		key:       sum[:32],
+		chainCode: sum[32:],
+		parentFP:  []byte{0, 0, 0, 0},
+		private:   true,
+	}, nil
+}
+
+//NewMasterFromMnemonic returns the master key of the mnemonic and passphrase.
+func NewMasterFromMnemonic(mnemonic, passphrase string) (*ExtendedKey, error) {
+	seed, err := NewSeed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return NewMaster(seed)
+}
+
+//IsPrivate reports whether the key holds a private key.
+func (k *ExtendedKey) IsPrivate() bool {
+	return k.private
+}
+
+//Depth returns the number of derivations from the master key.
+func (k *ExtendedKey) Depth() uint8 {
+	return k.depth
+}
+
+//Index returns the child index the key was derived with.
+func (k *ExtendedKey) Index() uint32 {
+	return k.index
+}
+
+func (k *ExtendedKey) pubKeyBytes() []byte {
+	if !k.private {
+		return k.key
+	}
+	x, y := btcec.S256().ScalarBaseMult(k.key)
+	pub := btcec.PublicKey{Curve: btcec.S256(), X: x, Y: y}
+	return pub.SerializeCompressed()
+}
+
+func hash160(data []byte) []byte {
+	sha := sha256.Sum256(data)
+	h := ripemd160.New()
+	h.Write(sha[:])
+	return h.Sum(nil)
+}
+
+//Child derives the child key with the given index, an index from HardenedKeyStart
+//on derives a hardened child that needs a private key.
+func (k *ExtendedKey) Child(i uint32) (*ExtendedKey, error) {
+	hardened := i >= HardenedKeyStart
+	if hardened && !k.private {
+		return nil, ErrHardenedPublic
+	}
+
+	data := make([]byte, 0, 37)
+	if hardened {
+		data = append(data, 0x00)
+		data = append(data, k.key...)
+	} else {
+		data = append(data, k.pubKeyBytes()...)
+	}
+	var index [4]byte
+	binary.BigEndian.PutUint32(index[:], i)
+	data = append(data, index[:]...)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	curve := btcec.S256()
+	il := new(big.Int).SetBytes(sum[:32])
+	if il.Cmp(curve.N) >= 0 {
+		return nil, ErrInvalidChild
+	}
+
+	var childKey []byte
+	if k.private {
+		child := new(big.Int).Add(il, new(big.Int).SetBytes(k.key))
+		child.Mod(child, curve.N)
+		if child.Sign() == 0 {
+			return nil, ErrInvalidChild
+		}
+		childKey = make([]byte, 32)
+		raw := child.Bytes()
+		copy(childKey[32-len(raw):], raw)
+	} else {
+		parent, err := btcec.ParsePubKey(k.key, curve)
+		if err != nil {
+			return nil, err
+		}
+		ilx, ily := curve.ScalarBaseMult(sum[:32])
+		x, y := curve.Add(ilx, ily, parent.X, parent.Y)
+		if x.Sign() == 0 && y.Sign() == 0 {
+			return nil, ErrInvalidChild
+		}
+		pub := btcec.PublicKey{Curve: curve, X: x, Y: y}
+		childKey = pub.SerializeCompressed()
+	}
+
+	return &ExtendedKey{
+		key:       childKey,
+		chainCode: sum[32:],
+		depth:     k.depth + 1,
+		parentFP:  hash160(k.pubKeyBytes())[:4],
+		index:     i,
+		private:   k.private,
+	}, nil
+}
+
+//ParsePath parses a path like m/44'/0'/0'/0/7, hardened indexes are marked with ' or H.
+//The leading m is optional.
+func ParsePath(path string) ([]uint32, error) {
+	path = strings.TrimSpace(path)
+	if path == "" || path == "m" {
+		return nil, nil
+	}
+	parts := strings.Split(strings.TrimPrefix(path, "m/"), "/")
+	indexes := make([]uint32, 0, len(parts))
+	for _, part := range parts {
+		var offset uint32
+		if strings.HasSuffix(part, "'") || strings.HasSuffix(part, "H") || strings.HasSuffix(part, "h") {
+			offset = HardenedKeyStart
+			part = part[:len(part)-1]
+		}
+		i, err := strconv.ParseUint(part, 10, 32)
+		if err != nil || uint32(i) >= HardenedKeyStart {
+			return nil, errors.Wrapf(ErrInvalidPath, "%q", path)
+		}
+		indexes = append(indexes, uint32(i)+offset)
+	}
+	return indexes, nil
+}
+
+//Derive derives the key at the path relative to k, see ParsePath.
+func (k *ExtendedKey) Derive(path string) (*ExtendedKey, error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	key := k
+	for _, i := range indexes {
+		if key, err = key.Child(i); err != nil {
+			return nil, errors.Wrapf(err, "path %s", path)
+		}
+	}
+	return key, nil
+}
+
+//Neuter returns the public extended key, it derives the same public keys
+//along non-hardened paths without giving access to the private keys.
+func (k *ExtendedKey) Neuter() *ExtendedKey {
+	if !k.private {
+		return k
+	}
+	return &ExtendedKey{
+		key:       k.pubKeyBytes(),
+		chainCode: k.chainCode,
+		depth:     k.depth,
+		parentFP:  k.parentFP,
+		index:     k.index,
+	}
+}
+
+//PrivateKey returns the private key.
+func (k *ExtendedKey) PrivateKey() (*btcec.PrivateKey, error) {
+	if !k.private {
+		return nil, ErrNotPrivate
+	}
+	priv, _ := btcec.PrivKeyFromBytes(btcec.S256(), k.key)
+	return priv, nil
+}
+
+//PublicKey returns the public key.
+func (k *ExtendedKey) PublicKey() (*btcec.PublicKey, error) {
+	return btcec.ParsePubKey(k.pubKeyBytes(), btcec.S256())
+}
+
+//WIF returns the private key in WIF.
+func (k *ExtendedKey) WIF() (string, error) {
+	if !k.private {
+		return "", ErrNotPrivate
+	}
+	return wif.Encode(k.key)
+}
+
+//PublicKeyString returns the public key in the 'BEO...' form.
+func (k *ExtendedKey) PublicKeyString() (string, error) {
+	pub, err := k.PublicKey()
+	if err != nil {
+		return "", err
+	}
+	return transactions.PublicKeyString(pub), nil
+}
+
+//String returns the key in the xprv/xpub form of BIP-32.
+func (k *ExtendedKey) String() string {
+	buf := make([]byte, 0, serializedKeyLen+4)
+	if k.private {
+		buf = append(buf, versionPrivate...)
+	} else {
+		buf = append(buf, versionPublic...)
+	}
+	buf = append(buf, k.depth)
+	buf = append(buf, k.parentFP...)
+	var index [4]byte
+	binary.BigEndian.PutUint32(index[:], k.index)
+	buf = append(buf, index[:]...)
+	buf = append(buf, k.chainCode...)
+	if k.private {
+		buf = append(buf, 0x00)
+	}
+	buf = append(buf, k.key...)
+
+	checksum := sha256.Sum256(buf)
+	checksum = sha256.Sum256(checksum[:])
+	return base58.Encode(append(buf, checksum[:4]...))
+}
+
+//ParseExtendedKey reads a key in the xprv/xpub form.
+func ParseExtendedKey(s string) (*ExtendedKey, error) {
+	decoded := base58.Decode(s)
+	if len(decoded) != serializedKeyLen+4 {
+		return nil, ErrInvalidExtKey
+	}
+	payload, checksum := decoded[:serializedKeyLen], decoded[serializedKeyLen:]
+	expected := sha256.Sum256(payload)
+	expected = sha256.Sum256(expected[:])
+	if !bytes.Equal(checksum, expected[:4]) {
+		return nil, ErrExtKeyChecksum
+	}
+
+	k := &ExtendedKey{
+		depth:     payload[4],
+		parentFP:  append([]byte{}, payload[5:9]...),
+		index:     binary.BigEndian.Uint32(payload[9:13]),
+		chainCode: append([]byte{}, payload[13:45]...),
+	}
+	keyData := payload[45:78]
+	switch {
+	case bytes.Equal(payload[:4], versionPrivate) && keyData[0] == 0x00:
+		k.private = true
+		k.key = append([]byte{}, keyData[1:]...)
+		d := new(big.Int).SetBytes(k.key)
+		if d.Sign() == 0 || d.Cmp(btcec.S256().N) >= 0 {
+			return nil, ErrInvalidExtKey
+		}
+	case bytes.Equal(payload[:4], versionPublic):
+		if _, err := btcec.ParsePubKey(keyData, btcec.S256()); err != nil {
+			return nil, errors.Wrap(ErrInvalidExtKey, err.Error())
+		}
+		k.key = append([]byte{}, keyData...)
+	default:
+		return nil, ErrInvalidExtKey
+	}
+	return k, nil
+}
+
+//KeyPair is a derived Beowulf key pair
+type KeyPair struct {
+	Path       string
+	PrivateKey string // WIF, empty when derived from a public extended key
+	PublicKey  string // BEO...
+}
+
+//DeriveKeyPair derives the key pair at the path relative to k.
+func (k *ExtendedKey) DeriveKeyPair(path string) (*KeyPair, error) {
+	child, err := k.Derive(path)
+	if err != nil {
+		return nil, err
+	}
+	pair := &KeyPair{Path: path}
+	if pair.PublicKey, err = child.PublicKeyString(); err != nil {
+		return nil, err
+	}
+	if child.private {
+		if pair.PrivateKey, err = child.WIF(); err != nil {
+			return nil, err
+		}
+	}
+	return pair, nil
+}
+
+//DeriveKeyPairs derives count key pairs at the paths base/from ... base/from+count-1,
+//e.g. the keys of deposit accounts. The derivation from a public extended key
+//yields the public keys only.
+func (k *ExtendedKey) DeriveKeyPairs(base string, from, count uint32) ([]*KeyPair, error) {
+	parent, err := k.Derive(base)
+	if err != nil {
+		return nil, err
+	}
+	base = strings.TrimSuffix(base, "/")
+	if base == "" {
+		base = "m"
+	}
+	pairs := make([]*KeyPair, 0, count)
+	for i := from; i < from+count; i++ {
+		pair, err := parent.DeriveKeyPair(strconv.FormatUint(uint64(i), 10))
+		if err != nil {
+			return nil, errors.Wrapf(err, "index %d", i)
+		}
+		pair.Path = fmt.Sprintf("%s/%d", base, i)
+		pairs = append(pairs, pair)
+	}
+	return pairs, nil
+}
@@ -0,0 +1,164 @@
+package hdwallet
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// Test vectors of BIP-39, the seeds use the passphrase TREZOR
+var mnemonicVectors = []struct {
+	entropy  string
+	mnemonic string
+	seed     string
+}{
+	{
+		entropy:  "00000000000000000000000000000000",
+		mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		seed:     "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+	},
+	{
+		entropy:  "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+	},
+	{
+		entropy:  "80808080808080808080808080808080",
+		mnemonic: "letter advice cage absurd amount doctor acoustic avoid letter advice cage above",
+	},
+	{
+		entropy:  "ffffffffffffffffffffffffffffffff",
+		mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong",
+	},
+	{
+		entropy:  "000000000000000000000000000000000000000000000000",
+		mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon agent",
+	},
+	{
+		entropy:  "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote",
+	},
+	{
+		entropy:  "9e885d952ad362caeb4efe34a8e91bd2",
+		mnemonic: "ozone drill grab fiber curtain grace pudding thank cruise elder eight picnic",
+	},
+	{
+		entropy:  "6610b25967cdcca9d59875f5cb50b0ea75433311869e930b",
+		mnemonic: "gravity machine north sort system female filter attitude volume fold club stay feature office ecology stable narrow fog",
+	},
+	{
+		entropy:  "68a79eaca2324873eacc50cb9c6eca8cc68ea5d936f98787c60c7ebc74e6ce7c",
+		mnemonic: "hamster diagram private dutch cause delay private meat slide toddler razor book happy fancy gospel tennis maple dilemma loan word shrug inflict delay length",
+	},
+	{
+		entropy:  "f585c11aec520db57dd353c69554b21a89b20fb0650966fa0a9d6f74fd989d8f",
+		mnemonic: "void come effort suffer camp survey warrior heavy shoot primary clutch crush open amazing screen patrol group space point ten exist slush involve unfold",
+	},
+}
+
+func TestMnemonicVectors(t *testing.T) {
+	for _, v := range mnemonicVectors {
+		entropy, _ := hex.DecodeString(v.entropy)
+		mnemonic, err := NewMnemonic(entropy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mnemonic != v.mnemonic {
+			t.Errorf("NewMnemonic(%s) = %q, want %q", v.entropy, mnemonic, v.mnemonic)
+		}
+
+		decoded, err := EntropyFromMnemonic(v.mnemonic)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(decoded) != v.entropy {
+			t.Errorf("EntropyFromMnemonic(%q) = %x", v.mnemonic, decoded)
+		}
+
+		if v.seed != "" {
+			seed, err := NewSeed(v.mnemonic, "TREZOR")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if hex.EncodeToString(seed) != v.seed {
+				t.Errorf("NewSeed(%q) = %x", v.mnemonic, seed)
+			}
+		}
+	}
+
+	if err := ValidateMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon"); err != ErrChecksum {
+		t.Errorf("bad checksum: %v", err)
+	}
+	if err := ValidateMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon beowulf"); err == nil {
+		t.Error("unknown word accepted")
+	}
+}
+
+// Test vector 1 of BIP-32
+func TestDeriveVectors(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := NewMaster(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vectors := []struct {
+		path string
+		xprv string
+		xpub string
+	}{
+		{"m", "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi", "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8"},
+		{"m/0H", "xprv9uHRZZhk6KAJC1avXpDAp4MDc3sQKNxDiPvvkX8Br5ngLNv1TxvUxt4cV1rGL5hj6KCesnDYUhd7oWgT11eZG7XnxHrnYeSvkzY7d2bhkJ7", "xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw"},
+		{"m/0'/1", "xprv9wTYmMFdV23N2TdNG573QoEsfRrWKQgWeibmLntzniatZvR9BmLnvSxqu53Kw1UmYPxLgboyZQaXwTCg8MSY3H2EU4pWcQDnRnrVA1xe8fs", "xpub6ASuArnXKPbfEwhqN6e3mwBcDTgzisQN1wXN9BJcM47sSikHjJf3UFHKkNAWbWMiGj7Wf5uMash7SyYq527Hqck2AxYysAA7xmALppuCkwQ"},
+	}
+	for _, v := range vectors {
+		key, err := master.Derive(v.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if key.String() != v.xprv {
+			t.Errorf("%s: xprv %s", v.path, key)
+		}
+		if key.Neuter().String() != v.xpub {
+			t.Errorf("%s: xpub %s", v.path, key.Neuter())
+		}
+
+		parsed, err := ParseExtendedKey(v.xprv)
+		if err != nil || parsed.String() != v.xprv {
+			t.Errorf("%s: ParseExtendedKey = %v, %v", v.path, parsed, err)
+		}
+	}
+}
+
+func TestDeriveKeyPairs(t *testing.T) {
+	master, err := NewMasterFromMnemonic(mnemonicVectors[0].mnemonic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := master.Derive("m/44'/0'/0'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pairs, err := master.DeriveKeyPairs("m/44'/0'/0'/0", 5, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	public, err := account.Neuter().DeriveKeyPairs("0", 5, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, pair := range pairs {
+		if pair.PrivateKey == "" || public[i].PrivateKey != "" {
+			t.Fatalf("unexpected private keys %+v %+v", pair, public[i])
+		}
+		if pair.PublicKey != public[i].PublicKey {
+			t.Errorf("%s: public derivation gives %s instead of %s", pair.Path, public[i].PublicKey, pair.PublicKey)
+		}
+	}
+	if pairs[0].Path != "m/44'/0'/0'/0/5" {
+		t.Errorf("unexpected path %s", pairs[0].Path)
+	}
+
+	if _, err := account.Neuter().Derive("0'"); err == nil {
+		t.Error("hardened derivation from a public key")
+	}
+}
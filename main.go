@@ -1,10 +1,11 @@
 package main
 
 import (
-	"beowulf-go/api"
-	"beowulf-go/client"
-	"beowulf-go/transactions"
-	"beowulf-go/util"
+	"github.com/thanhxeon2470/beowulf-go/api"
+	"github.com/thanhxeon2470/beowulf-go/client"
+	"github.com/thanhxeon2470/beowulf-go/transactions"
+	"github.com/thanhxeon2470/beowulf-go/types"
+	"github.com/thanhxeon2470/beowulf-go/util"
 	"encoding/json"
 	"fmt"
 	"github.com/shettyh/threadpool"
@@ -602,7 +603,7 @@ func ZeroCoin() {
 		f_account, _ := fromCli.GetAccount(name)
 		is_bwf := false
 		bwf, _ := util.ParseBalance(f_account.Balance)
-		if bwf > 0 {
+		if bwf.Sign() > 0 {
 			fmt.Println("ZeroCoin BWF of: ", name)
 			_, err := fromCli.Transfer(name, creator, memo, f_account.Balance, "0.01000 W")
 			if err != nil {
@@ -611,14 +612,14 @@ func ZeroCoin() {
 			is_bwf = true
 		}
 		w, _ := util.ParseBalance(f_account.WdBalance)
-		if w > 0 {
+		if w.Sign() > 0 {
 			fmt.Println("ZeroCoin W of: ", name)
+			fee := types.MustParseAsset("0.01000 W")
 			if is_bwf {
-				w = w - 0.02
-			} else {
-				w = w - 0.01
+				fee, _ = fee.MulRatio(2, 1)
 			}
-			_, err1 := fromCli.Transfer(name, creator, memo, util.FormatBalance(w, W), "0.01000 W")
+			w, _ = w.Sub(fee)
+			_, err1 := fromCli.Transfer(name, creator, memo, util.FormatBalance(w), "0.01000 W")
 			if err1 != nil {
 				fmt.Println(err1)
 			}
@@ -645,14 +646,14 @@ func CheckCoin() {
 	fmt.Println("CheckCoin 100 Account complete:", count)
 }
 
-func CheckTotalCoin() (float64, float64) {
+func CheckTotalCoin() (*types.Asset, *types.Asset) {
 	time.Sleep(1 * time.Second)
 
 	cli, _ := client.NewClient(url, true)
 	defer cli.Close()
 
-	wbfTotal := 0.0
-	wTotal := 0.0
+	wbfTotal := types.NewAsset(0, 5, BWF)
+	wTotal := types.NewAsset(0, 5, W)
 	name := "bwc"
 	count := 0
 	for i := 0; i < NI; i++ {
@@ -661,14 +662,16 @@ func CheckTotalCoin() (float64, float64) {
 		if err == nil {
 			count++
 		}
-		bwf, _ := util.ParseBalance(account.Balance)
-		wbfTotal += bwf
-		w, _ := util.ParseBalance(account.WdBalance)
-		wTotal += w
+		if bwf, err := util.ParseBalance(account.Balance); err == nil && bwf.Symbol == BWF {
+			wbfTotal, _ = wbfTotal.Add(bwf)
+		}
+		if w, err := util.ParseBalance(account.WdBalance); err == nil && w.Symbol == W {
+			wTotal, _ = wTotal.Add(w)
+		}
 	}
 	fmt.Println("CheckTotalCoin 100 Account complete:", count)
-	fmt.Println("Total BWF:", util.FormatBalance(wbfTotal, BWF))
-	fmt.Println("Total W:", util.FormatBalance(wTotal, W))
+	fmt.Println("Total BWF:", util.FormatBalance(wbfTotal))
+	fmt.Println("Total W:", util.FormatBalance(wTotal))
 	return wbfTotal, wTotal
 }
 
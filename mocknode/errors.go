@@ -0,0 +1,125 @@
+package mocknode
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The codes of the errors answered by the node
+const (
+	codeRPCException     = -32000
+	codeMethodNotFound   = -32601
+	codeInvalidParams    = -32602
+	codeAssertException  = 10
+	codeUnknownKey       = 13
+	codeMissingOwnerAuth = 3020000
+)
+
+// rpcError is a JSON-RPC error shaped like the fc exceptions of the node,
+// it decodes into types.RPCError
+type rpcError struct {
+	Code    int          `json:"code"`
+	Message string       `json:"message"`
+	Data    rpcErrorData `json:"data"`
+}
+
+type rpcErrorData struct {
+	Code    int             `json:"code"`
+	Name    string          `json:"name"`
+	Message string          `json:"message"`
+	Stack   []rpcErrorFrame `json:"stack"`
+}
+
+type rpcErrorFrame struct {
+	Context rpcErrorContext        `json:"context"`
+	Format  string                 `json:"format"`
+	Data    map[string]interface{} `json:"data"`
+}
+
+type rpcErrorContext struct {
+	Level      string `json:"level"`
+	File       string `json:"file"`
+	Line       int    `json:"line"`
+	Method     string `json:"method"`
+	Hostname   string `json:"hostname"`
+	ThreadName string `json:"thread_name"`
+	Timestamp  string `json:"timestamp"`
+}
+
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+func frame(file string, line int, method, format string, data map[string]interface{}) rpcErrorFrame {
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	return rpcErrorFrame{
+		Context: rpcErrorContext{Level: "error", File: file, Line: line, Method: method, Hostname: "", ThreadName: "th_a"},
+		Format:  format,
+		Data:    data,
+	}
+}
+
+// format replaces the ${name} fields of the format like fc does
+func format(f string, data map[string]interface{}) string {
+	for key, value := range data {
+		f = strings.Replace(f, "${"+key+"}", fmt.Sprint(value), -1)
+	}
+	return f
+}
+
+// assertError is a failed FC_ASSERT(expr, message)
+func assertError(expr, message, file string, line int, method string, data map[string]interface{}) *rpcError {
+	f := expr + ": " + message
+	return &rpcError{
+		Code:    codeRPCException,
+		Message: "Assert Exception:" + format(f, data),
+		Data: rpcErrorData{
+			Code:    codeAssertException,
+			Name:    "assert_exception",
+			Message: "Assert Exception",
+			Stack:   []rpcErrorFrame{frame(file, line, method, f, data)},
+		},
+	}
+}
+
+// unknownAccountError is thrown by the database when an account does not exist
+func unknownAccountError(name string) *rpcError {
+	return &rpcError{
+		Code:    codeRPCException,
+		Message: "unknown key:unknown key: ",
+		Data: rpcErrorData{
+			Code:    codeUnknownKey,
+			Name:    "N5boost16exception_detail10clone_implINS0_19error_info_injectorISt12out_of_rangeEEEE",
+			Message: "unknown key",
+			Stack: []rpcErrorFrame{
+				frame("exceptions.hpp", 255, "handle", "unknown key:unknown key: ", map[string]interface{}{"what": "unknown key"}),
+				frame("database.cpp", 523, "get_account", "", map[string]interface{}{"name": name}),
+			},
+		},
+	}
+}
+
+// missingOwnerAuthError is thrown when the signatures do not satisfy the owner authority of the account
+func missingOwnerAuthError(name string) *rpcError {
+	data := map[string]interface{}{"id": name}
+	return &rpcError{
+		Code:    codeRPCException,
+		Message: "missing required owner authority:Missing Owner Authority " + name,
+		Data: rpcErrorData{
+			Code:    codeMissingOwnerAuth,
+			Name:    "tx_missing_owner_auth",
+			Message: "missing required owner authority",
+			Stack:   []rpcErrorFrame{frame("authority_verification.hpp", 94, "verify_authority", "Missing Owner Authority ${id}", data)},
+		},
+	}
+}
+
+func methodNotFoundError(method string) *rpcError {
+	return &rpcError{Code: codeMethodNotFound, Message: "Could not find method " + method}
+}
+
+func invalidParamsError(err error) *rpcError {
+	return &rpcError{Code: codeInvalidParams, Message: "Invalid params: " + err.Error()}
+}
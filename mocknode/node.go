@@ -0,0 +1,450 @@
+//Package mocknode is an in-process Beowulf node for tests running offline. It answers the
+//condenser_api and sidechain requests of api.API over HTTP and websocket from an in-memory
+//chain: broadcast transactions are checked (expiration, TaPoS, duplicates, owner signatures),
+//applied to the balances and included in blocks produced on a timer or by ProduceBlock.
+package mocknode
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+	"github.com/thanhxeon2470/beowulf-go/api"
+	"github.com/thanhxeon2470/beowulf-go/config"
+	"github.com/thanhxeon2470/beowulf-go/transactions"
+	"github.com/thanhxeon2470/beowulf-go/types"
+)
+
+// The defaults of the options of New
+const (
+	DefaultBlockInterval     = 3 * time.Second
+	DefaultIrreversibleDepth = 1
+	DefaultSupernode         = "initminer"
+)
+
+// maxTimeUntilExpiration is BEOWULF_MAX_TIME_UNTIL_EXPIRATION
+const maxTimeUntilExpiration = time.Hour
+
+// timeLayout is the layout of the times answered by the node
+const timeLayout = "2006-01-02T15:04:05"
+
+//Option configures the Node created by New
+type Option func(*Node)
+
+//WithChainID sets the chain the signatures are checked against, the testnet by default.
+func WithChainID(chainID string) Option {
+	return func(n *Node) {
+		n.chainID = chainID
+	}
+}
+
+//WithBlockInterval sets how often a block is produced, 0 means only ProduceBlock produces them.
+func WithBlockInterval(interval time.Duration) Option {
+	return func(n *Node) {
+		n.blockInterval = interval
+	}
+}
+
+//WithIrreversibleDepth sets how many blocks the last irreversible block is behind the head block.
+func WithIrreversibleDepth(depth uint32) Option {
+	return func(n *Node) {
+		n.irreversibleDepth = depth
+	}
+}
+
+//WithSupernode sets the account producing the blocks, it receives the fees.
+func WithSupernode(name string) Option {
+	return func(n *Node) {
+		n.supernode = name
+	}
+}
+
+//Node is an in-memory chain served over HTTP and websocket.
+//The block ids only depend on the chain and the block number, so a reference block
+//cached by a client stays valid across the nodes of a test process.
+type Node struct {
+	chainID           string
+	blockInterval     time.Duration
+	irreversibleDepth uint32
+	supernode         string
+
+	server   *httptest.Server
+	upgrader websocket.Upgrader
+
+	// produceMutex serializes the production of blocks
+	produceMutex sync.Mutex
+
+	mutex        sync.Mutex
+	state        *state // as of the head block
+	pendingState *state // with the pending transactions applied
+	pending      []*transaction
+	blocks       []*api.Block // blocks[i] is the block i+1
+	transactions map[string]*transaction
+	conns        map[*conn]bool
+	sidechain    *sidechain
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     sync.WaitGroup
+}
+
+// transaction is a transaction accepted by the node
+type transaction struct {
+	id       string
+	tx       *types.Transaction
+	blockNum uint32        // 0 while pending
+	trxNum   uint32        // the index in its block
+	expired  uint32        // the block it expired in, 0 if it did not
+	err      *rpcError     // why it was dropped from the pending transactions
+	done     chan struct{} // closed once it is included, expired or dropped
+}
+
+//New starts a node with a genesis block and the supernode account.
+//It must be closed with Close.
+func New(opts ...Option) *Node {
+	n := &Node{
+		chainID:           config.CHAIN_ID_TESTNET,
+		blockInterval:     DefaultBlockInterval,
+		irreversibleDepth: DefaultIrreversibleDepth,
+		supernode:         DefaultSupernode,
+		state:             newState(),
+		transactions:      make(map[string]*transaction),
+		conns:             make(map[*conn]bool),
+		stop:              make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	n.state.accounts[n.supernode] = &account{
+		info:     api.AccountInfo{Name: n.supernode, Owner: &types.Authority{KeyAuths: types.StringInt64Map{}, AccountAuths: types.StringInt64Map{}}},
+		balances: make(map[string]*types.Asset),
+	}
+	n.pendingState = n.state.clone()
+	genesis := n.newBlock(1, strings.Repeat("0", 40), time.Now().UTC(), nil)
+	n.blocks = append(n.blocks, genesis)
+	n.sidechain = newSidechain(genesis)
+
+	n.server = httptest.NewServer(http.HandlerFunc(n.serveHTTP))
+	if n.blockInterval > 0 {
+		n.done.Add(1)
+		go n.produce()
+	}
+	return n
+}
+
+//URL returns the HTTP endpoint of the node.
+func (n *Node) URL() string {
+	return n.server.URL
+}
+
+//WebsocketURL returns the websocket endpoint of the node.
+func (n *Node) WebsocketURL() string {
+	return "ws" + strings.TrimPrefix(n.server.URL, "http")
+}
+
+//Close stops producing blocks, closes the websocket connections and shuts the server down.
+func (n *Node) Close() {
+	n.stopOnce.Do(func() {
+		close(n.stop)
+	})
+	n.done.Wait()
+
+	n.mutex.Lock()
+	for c := range n.conns {
+		c.ws.Close()
+	}
+	n.mutex.Unlock()
+	n.server.Close()
+}
+
+func (n *Node) produce() {
+	defer n.done.Done()
+	ticker := time.NewTicker(n.blockInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-n.stop:
+			return
+		case <-ticker.C:
+			n.ProduceBlock()
+		}
+	}
+}
+
+//CreateAccount adds an account owned by the public key with the balances, e.g. "10.00000 BWF",
+//as if it was in the genesis state.
+func (n *Node) CreateAccount(name, publicKey string, balances ...string) error {
+	a := &account{
+		info: api.AccountInfo{
+			Name: name,
+			Owner: &types.Authority{
+				AccountAuths:    types.StringInt64Map{},
+				KeyAuths:        types.StringInt64Map{publicKey: 1},
+				WeightThreshold: 1,
+			},
+		},
+		balances: make(map[string]*types.Asset),
+	}
+	for _, balance := range balances {
+		asset, err := types.ParseAsset(balance)
+		if err != nil {
+			return err
+		}
+		a.balances[asset.Symbol] = asset
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if _, ok := n.state.accounts[name]; ok {
+		return errors.Errorf("account %s already exists", name)
+	}
+	if _, ok := n.pendingState.accounts[name]; ok {
+		return errors.Errorf("account %s already exists", name)
+	}
+	n.state.accounts[name] = a
+	pending := *a
+	pending.balances = make(map[string]*types.Asset, len(a.balances))
+	for symbol, balance := range a.balances {
+		b := *balance
+		pending.balances[symbol] = &b
+	}
+	n.pendingState.accounts[name] = &pending
+	return nil
+}
+
+//Balance returns the balance of the account with the pending transactions applied,
+//like the node answers get_accounts.
+func (n *Node) Balance(name, symbol string) (*types.Asset, error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	a, err := n.pendingState.account(name)
+	if err != nil {
+		return nil, errors.Errorf("unknown account %s", name)
+	}
+	return a.balance(symbol, Precision), nil
+}
+
+//HeadBlockNum returns the number of the head block.
+func (n *Node) HeadBlockNum() uint32 {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	return n.head().Number
+}
+
+func (n *Node) head() *api.Block {
+	return n.blocks[len(n.blocks)-1]
+}
+
+func (n *Node) lastIrreversibleBlockNum() uint32 {
+	head := n.head().Number
+	if head <= n.irreversibleDepth {
+		return 1
+	}
+	return head - n.irreversibleDepth
+}
+
+func (n *Node) block(num uint32) *api.Block {
+	if num == 0 || int(num) > len(n.blocks) {
+		return nil
+	}
+	return n.blocks[num-1]
+}
+
+func (n *Node) newBlock(num uint32, previous string, timestamp time.Time, included []*transaction) *api.Block {
+	timestamp = timestamp.Truncate(time.Second)
+	digest := sha256.Sum256([]byte(n.chainID + previous))
+	block := &api.Block{
+		Number:             num,
+		Previous:           previous,
+		Timestamp:          &types.Time{Time: &timestamp},
+		Supernode:          n.supernode,
+		BlockReward:        types.NewAsset(0, Precision, SymbolBWF),
+		Extensions:         [][]interface{}{},
+		SupernodeSignature: strings.Repeat("0", 130),
+		Transactions:       []*types.Transaction{},
+		BlockId:            fmt.Sprintf("%08x", num) + hex.EncodeToString(digest[:16]),
+		TransactionIds:     []string{},
+	}
+	merkle := sha256.New()
+	for _, t := range included {
+		block.Transactions = append(block.Transactions, t.tx)
+		block.TransactionIds = append(block.TransactionIds, t.id)
+		merkle.Write([]byte(t.id))
+	}
+	block.TransactionMerkleRoot = strings.Repeat("0", 40)
+	if len(included) > 0 {
+		block.TransactionMerkleRoot = hex.EncodeToString(merkle.Sum(nil)[:20])
+	}
+	return block
+}
+
+//ProduceBlock includes the pending transactions in a new head block and returns it.
+//Expired transactions and those that no longer apply are dropped.
+func (n *Node) ProduceBlock() *api.Block {
+	n.produceMutex.Lock()
+	defer n.produceMutex.Unlock()
+
+	n.mutex.Lock()
+	now := time.Now().UTC()
+	head := n.head()
+	num := head.Number + 1
+	st := n.state.clone()
+	var included, finished []*transaction
+	for _, t := range n.pending {
+		finished = append(finished, t)
+		if !t.tx.Expiration.After(now) {
+			t.expired = num
+			continue
+		}
+		if err := st.apply(t.tx, n.supernode); err != nil {
+			t.err = err
+			delete(n.transactions, t.id)
+			continue
+		}
+		t.blockNum, t.trxNum = num, uint32(len(included))
+		included = append(included, t)
+	}
+	block := n.newBlock(num, head.BlockId, now, included)
+	n.blocks = append(n.blocks, block)
+	n.state = st
+	n.pendingState = st.clone()
+	n.pending = nil
+	conns := make([]*conn, 0, len(n.conns))
+	for c := range n.conns {
+		conns = append(conns, c)
+	}
+	n.mutex.Unlock()
+
+	n.produceSidechainBlock(block, included)
+	for _, t := range finished {
+		close(t.done)
+	}
+	header := blockHeader(block)
+	for _, c := range conns {
+		c.notify(header)
+	}
+	return block
+}
+
+// push checks the transaction and adds it to the pending transactions
+func (n *Node) push(tx *types.Transaction) (*transaction, *rpcError) {
+	id, err := (&transactions.SignedTransaction{Transaction: tx}).ID()
+	if err != nil {
+		return nil, invalidParamsError(err)
+	}
+	if tx.Expiration == nil || tx.Expiration.Time == nil {
+		return nil, invalidParamsError(errors.New("transaction has no expiration"))
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	now := time.Now().UTC()
+	expiration := *tx.Expiration.Time
+	data := map[string]interface{}{"now": now.Format(timeLayout), "trx.expiration": expiration.Format(timeLayout)}
+	if !expiration.After(now) {
+		return nil, assertError("now < trx.expiration", "now: ${now} trx.exp: ${trx.expiration}",
+			"database.cpp", 3180, "_apply_transaction", data)
+	}
+	if expiration.After(now.Add(maxTimeUntilExpiration)) {
+		return nil, assertError("trx.expiration <= now + fc::seconds(BEOWULF_MAX_TIME_UNTIL_EXPIRATION)",
+			"now: ${now} trx.exp: ${trx.expiration}", "database.cpp", 3176, "_apply_transaction", data)
+	}
+	if err := n.checkTaPoS(tx); err != nil {
+		return nil, err
+	}
+	if _, ok := n.transactions[id]; ok {
+		return nil, assertError("trx_idx.indices().get<by_trx_id>().find(trx_id) == trx_idx.indices().get<by_trx_id>().end()",
+			"Duplicate transaction check failed", "database.cpp", 3163, "_apply_transaction", map[string]interface{}{"trx_ix": id})
+	}
+	if err := n.pendingState.verifyAuthority(tx, n.chainID); err != nil {
+		return nil, err
+	}
+	st := n.pendingState.clone()
+	if err := st.apply(tx, n.supernode); err != nil {
+		return nil, err
+	}
+	n.pendingState = st
+
+	t := &transaction{id: id, tx: tx, done: make(chan struct{})}
+	n.pending = append(n.pending, t)
+	n.transactions[id] = t
+	return t, nil
+}
+
+// checkTaPoS checks the transaction references a block of the chain
+func (n *Node) checkTaPoS(tx *types.Transaction) *rpcError {
+	for i := len(n.blocks) - 1; i >= 0; i-- {
+		if uint16(n.blocks[i].Number) != uint16(tx.RefBlockNum) {
+			continue
+		}
+		prefix, err := transactions.RefBlockPrefix(n.blocks[i].BlockId)
+		if err == nil && prefix == tx.RefBlockPrefix {
+			return nil
+		}
+		break
+	}
+	return assertError("trx.ref_block_prefix == tapos_block_summary.block_id._hash[1]", "",
+		"database.cpp", 3171, "validate_transaction", map[string]interface{}{"trx.ref_block_num": tx.RefBlockNum})
+}
+
+// status returns the status of the transaction like get_transaction_with_status
+func (n *Node) status(t *transaction) string {
+	lib := n.lastIrreversibleBlockNum()
+	switch {
+	case t.expired > 0 && t.expired <= lib:
+		return api.TransactionStatusExpiredIrreversible
+	case t.expired > 0:
+		return api.TransactionStatusExpiredReversible
+	case t.blockNum == 0:
+		return api.TransactionStatusWithinMempool
+	case t.blockNum <= lib:
+		return api.TransactionStatusWithinIrreversibleBlock
+	}
+	return api.TransactionStatusWithinReversibleBlock
+}
+
+func (n *Node) transactionResponse(t *transaction) *api.TransactionResponse {
+	tx := t.tx
+	refBlockNum, refBlockPrefix := tx.RefBlockNum, tx.RefBlockPrefix
+	createdTime := tx.CreatedTime
+	resp := &api.TransactionResponse{
+		RefBlockNum:    &refBlockNum,
+		RefBlockPrefix: &refBlockPrefix,
+		Expiration:     tx.Expiration,
+		Operations:     &tx.Operations,
+		Extensions:     tx.Extensions,
+		CreatedTime:    &createdTime,
+		Signatures:     tx.Signatures,
+		TransactionId:  t.id,
+	}
+	if t.blockNum > 0 {
+		blockNum, trxNum := types.UInt32(t.blockNum), types.UInt32(t.trxNum)
+		resp.BlockNum = &blockNum
+		resp.TransactionNum = &trxNum
+	}
+	return resp
+}
+
+// keyReferences returns the accounts whose owner authority has the key
+func (n *Node) keyReferences(key string) []string {
+	names := []string{}
+	for name, a := range n.pendingState.accounts {
+		if a.info.Owner == nil {
+			continue
+		}
+		if _, ok := a.info.Owner.KeyAuths[key]; ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
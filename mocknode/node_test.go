@@ -0,0 +1,172 @@
+package mocknode_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/thanhxeon2470/beowulf-go/api"
+	"github.com/thanhxeon2470/beowulf-go/client"
+	"github.com/thanhxeon2470/beowulf-go/config"
+	"github.com/thanhxeon2470/beowulf-go/mocknode"
+	"github.com/thanhxeon2470/beowulf-go/nft"
+	"github.com/thanhxeon2470/beowulf-go/types"
+)
+
+func newAccount(t *testing.T, node *mocknode.Node, name string, balances ...string) *client.Keys {
+	wif := client.CreatePrivateKey(name, "owner", "password")
+	if err := node.CreateAccount(name, client.CreatePublicKey(config.ADDRESS_PREFIX, wif), balances...); err != nil {
+		t.Fatal(err)
+	}
+	return &client.Keys{OKey: []string{wif}}
+}
+
+func balance(t *testing.T, node *mocknode.Node, name, symbol string) string {
+	asset, err := node.Balance(name, symbol)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return asset.String()
+}
+
+func TestTransfer(t *testing.T) {
+	node := mocknode.New(mocknode.WithBlockInterval(0))
+	defer node.Close()
+	aliceKeys := newAccount(t, node, "alice", "100.00000 BWF", "1.00000 W")
+	newAccount(t, node, "bob")
+
+	cls, err := client.NewClient(node.URL(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cls.Close()
+	cls.SetKeys(aliceKeys)
+
+	resp, err := cls.Transfer("alice", "bob", "", "10.00000 BWF", "0.01000 W")
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := resp.Bresp.ID
+	status := func() string {
+		tx, err := cls.API.GetTransactionWithStatus(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tx.Status
+	}
+	if s := status(); s != api.TransactionStatusWithinMempool {
+		t.Errorf("status %s", s)
+	}
+	node.ProduceBlock()
+	if s := status(); s != api.TransactionStatusWithinReversibleBlock {
+		t.Errorf("status %s", s)
+	}
+	node.ProduceBlock()
+	if s := status(); s != api.TransactionStatusWithinIrreversibleBlock {
+		t.Errorf("status %s", s)
+	}
+
+	if b := balance(t, node, "bob", mocknode.SymbolBWF); b != "10.00000 BWF" {
+		t.Errorf("bob has %s", b)
+	}
+	if b := balance(t, node, "alice", mocknode.SymbolW); b != "0.99000 W" {
+		t.Errorf("alice has %s", b)
+	}
+	if b := balance(t, node, mocknode.DefaultSupernode, mocknode.SymbolW); b != "0.01000 W" {
+		t.Errorf("the supernode has %s", b)
+	}
+	account, err := cls.GetAccount("alice")
+	if err != nil || account.Balance != "90.00000 BWF" {
+		t.Errorf("alice %+v, %v", account, err)
+	}
+
+	_, err = cls.Transfer("alice", "bob", "", "1000.00000 BWF", "0.01000 W")
+	if !errors.Is(err, types.ErrInsufficientBalance) {
+		t.Errorf("overdraft: %v", err)
+	}
+	_, err = cls.Transfer("bob", "alice", "", "1.00000 BWF", "0.01000 W")
+	if !errors.Is(err, types.ErrMissingAuthority) || !strings.Contains(err.Error(), "Missing Owner Authority bob") {
+		t.Errorf("signed by alice for bob: %v", err)
+	}
+
+	tx, err := cls.CreateTrxTransfer("alice", "bob", "", "1.00000 BWF", "0.01000 W", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cls.SignTrx(tx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cls.API.BroadcastTransaction(tx.Transaction); err != nil {
+		t.Fatal(err)
+	}
+	_, err = cls.API.BroadcastTransaction(tx.Transaction)
+	if !errors.Is(err, types.ErrDuplicateTransaction) {
+		t.Errorf("rebroadcast: %v", err)
+	}
+}
+
+func TestWebsocketAndSidechain(t *testing.T) {
+	node := mocknode.New(mocknode.WithBlockInterval(20 * time.Millisecond))
+	defer node.Close()
+	keys := newAccount(t, node, "carol", "1.00000 W")
+	if err := node.Insert("nft", "nfts", map[string]interface{}{"symbol": "ART", "issuer": "carol"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := node.Insert("nft", "ARTinstances",
+		map[string]interface{}{"account": "carol"}, map[string]interface{}{"account": "dave"}); err != nil {
+		t.Fatal(err)
+	}
+	node.HandleContract(nft.ContractName, func(tx *api.NFTTransaction) api.NFTLogs {
+		if err := node.Insert("nft", "ARTinstances", map[string]interface{}{"account": tx.Sender}); err != nil {
+			return api.NFTLogs{Errors: []string{err.Error()}}
+		}
+		return api.NFTLogs{}
+	})
+
+	cls, err := client.NewClient(node.WebsocketURL(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cls.Close()
+	cls.SetKeys(keys)
+	cls.AsyncProtocol = false
+
+	headers := make(chan *api.BlockHeader, 16)
+	err = cls.API.SetBlockAppliedCallback(func(header *api.BlockHeader, err error) {
+		if err == nil {
+			select {
+			case headers <- header:
+			default:
+			}
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-headers:
+	case <-time.After(5 * time.Second):
+		t.Error("no block applied notice")
+	}
+
+	resp, err := cls.SendNFT("carol", "", "0.01000 W", nft.NewIssue("ART", "carol"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := cls.GetNFTTransaction(resp.Bresp.ID)
+	if err != nil || tx.Action != nft.ActionIssue || tx.Sender != "carol" || tx.Err() != nil {
+		t.Errorf("sidechain transaction %+v, %v", tx, err)
+	}
+
+	instances, err := cls.GetNFTBalance("carol", "ART", 10, 0)
+	if err != nil || len(*instances) != 2 {
+		t.Errorf("carol instances %+v, %v", instances, err)
+	}
+	var records []api.NFTInstance
+	params := api.Params{Contract: "nft", Table: "ARTinstances", Query: api.Query{}.Ne("account", "dave"),
+		Indexes: []api.Index{{Index: "_id", Descending: true}}}
+	if err := cls.API.FindAll(params, &records); err != nil || len(records) != 2 || *records[0].Id != 3 {
+		t.Errorf("records %+v, %v", records, err)
+	}
+}
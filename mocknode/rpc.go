@@ -0,0 +1,440 @@
+package mocknode
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+	"github.com/thanhxeon2470/beowulf-go/api"
+	"github.com/thanhxeon2470/beowulf-go/config"
+	"github.com/thanhxeon2470/beowulf-go/transactions"
+	"github.com/thanhxeon2470/beowulf-go/types"
+)
+
+type request struct {
+	ID     uint64          `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+type response struct {
+	ID     uint64      `json:"id"`
+	JSON   string      `json:"jsonrpc"`
+	Result interface{} `json:"result"`
+	Error  *rpcError   `json:"error,omitempty"`
+}
+
+// conn is a websocket connection, the notices of its callbacks are sent on it
+type conn struct {
+	ws *websocket.Conn
+
+	mutex     sync.Mutex // serializes the writes
+	callbacks []uint64
+}
+
+func (c *conn) write(data []byte) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	_ = c.ws.WriteMessage(websocket.TextMessage, data)
+}
+
+func (c *conn) notify(header api.BlockHeader) {
+	c.mutex.Lock()
+	callbacks := append([]uint64(nil), c.callbacks...)
+	c.mutex.Unlock()
+	for _, id := range callbacks {
+		data, _ := json.Marshal(response{ID: id, JSON: "2.0", Result: []api.BlockHeader{header}})
+		c.write(data)
+	}
+}
+
+func (n *Node) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if websocket.IsWebSocketUpgrade(r) {
+		n.serveWebsocket(w, r)
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(n.handleMessage(r.Context(), body, nil))
+}
+
+func (n *Node) serveWebsocket(w http.ResponseWriter, r *http.Request) {
+	ws, err := n.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	c := &conn{ws: ws}
+	n.mutex.Lock()
+	n.conns[c] = true
+	n.mutex.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		n.mutex.Lock()
+		delete(n.conns, c)
+		n.mutex.Unlock()
+		ws.Close()
+	}()
+	for {
+		_, message, err := ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		// a synchronous broadcast must not hold up the other requests of the connection
+		go func(message []byte) {
+			c.write(n.handleMessage(ctx, message, c))
+		}(message)
+	}
+}
+
+// handleMessage answers a request or a batch of requests
+func (n *Node) handleMessage(ctx context.Context, message []byte, c *conn) []byte {
+	var answer interface{}
+	if trimmed := bytes.TrimSpace(message); len(trimmed) > 0 && trimmed[0] == '[' {
+		var requests []request
+		if err := json.Unmarshal(trimmed, &requests); err != nil {
+			answer = response{JSON: "2.0", Error: invalidParamsError(err)}
+		} else {
+			responses := make([]response, len(requests))
+			for i, req := range requests {
+				responses[i] = n.handle(ctx, req, c)
+			}
+			answer = responses
+		}
+	} else {
+		var req request
+		if err := json.Unmarshal(trimmed, &req); err != nil {
+			answer = response{JSON: "2.0", Error: invalidParamsError(err)}
+		} else {
+			answer = n.handle(ctx, req, c)
+		}
+	}
+	data, _ := json.Marshal(answer)
+	return data
+}
+
+func (n *Node) handle(ctx context.Context, req request, c *conn) response {
+	resp := response{ID: req.ID, JSON: "2.0"}
+	result, err := n.call(ctx, req, c)
+	if err != nil {
+		resp.Error = err
+	} else {
+		resp.Result = result
+	}
+	return resp
+}
+
+// call dispatches the request: "call" with [api, method, args] is a condenser_api call,
+// "call" with [method, params] and any other method name a sidechain call
+func (n *Node) call(ctx context.Context, req request, c *conn) (interface{}, *rpcError) {
+	if req.Method != "call" {
+		return n.callSidechain(req.Method, req.Params)
+	}
+	var params []json.RawMessage
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		return nil, invalidParamsError(err)
+	}
+	switch len(params) {
+	case 3:
+		var apiID, method string
+		if err := json.Unmarshal(params[0], &apiID); err != nil {
+			return nil, invalidParamsError(err)
+		}
+		if err := json.Unmarshal(params[1], &method); err != nil {
+			return nil, invalidParamsError(err)
+		}
+		if apiID != "condenser_api" {
+			return nil, methodNotFoundError(apiID + "." + method)
+		}
+		return n.callCondenser(ctx, method, params[2], c)
+	case 2:
+		var method string
+		if err := json.Unmarshal(params[0], &method); err != nil {
+			return nil, invalidParamsError(err)
+		}
+		return n.callSidechain(method, params[1])
+	}
+	return nil, invalidParamsError(errors.Errorf("call takes 2 or 3 params, got %d", len(params)))
+}
+
+// decodeArgs decodes the first items of the JSON array of arguments into values
+func decodeArgs(data json.RawMessage, values ...interface{}) *rpcError {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return invalidParamsError(err)
+	}
+	if len(items) < len(values) {
+		return invalidParamsError(errors.Errorf("expected %d params, got %d", len(values), len(items)))
+	}
+	for i, v := range values {
+		if err := json.Unmarshal(items[i], v); err != nil {
+			return invalidParamsError(err)
+		}
+	}
+	return nil
+}
+
+// decodeTransaction decodes a broadcast transaction, its extensions are decoded
+// into their type so that the transaction serializes like it was signed
+func decodeTransaction(data json.RawMessage) (*types.Transaction, *rpcError) {
+	var tx types.Transaction
+	if err := json.Unmarshal(data, &tx); err != nil {
+		return nil, invalidParamsError(err)
+	}
+	for i, ext := range tx.Extensions {
+		raw, _ := json.Marshal(ext)
+		var extension types.ExtensionType
+		if err := json.Unmarshal(raw, &extension); err != nil {
+			return nil, invalidParamsError(err)
+		}
+		tx.Extensions[i] = &extension
+	}
+	return &tx, nil
+}
+
+func (n *Node) callCondenser(ctx context.Context, method string, args json.RawMessage, c *conn) (interface{}, *rpcError) {
+	switch method {
+	case "get_version":
+		return &api.Version{BlockchainVersion: "0.0.0", BeowulfRevision: "mocknode", FcRevision: "mocknode"}, nil
+
+	case "get_config":
+		return map[string]interface{}{
+			"BEOWULF_ADDRESS_PREFIX":            config.ADDRESS_PREFIX,
+			"BEOWULF_CHAIN_ID":                  n.chainID,
+			"BEOWULF_BLOCK_INTERVAL":            uint(n.blockInterval.Seconds()),
+			"BEOWULF_INIT_MINER_NAME":           n.supernode,
+			"BEOWULF_MAX_TIME_UNTIL_EXPIRATION": int(maxTimeUntilExpiration.Seconds()),
+			"BEOWULF_SYMBOL_BEOWULF":            SymbolBWF,
+			"BEOWULF_SYMBOL_WD":                 SymbolW,
+		}, nil
+
+	case "get_dynamic_global_properties":
+		n.mutex.Lock()
+		defer n.mutex.Unlock()
+		head := n.head()
+		supply := types.NewAsset(0, Precision, SymbolBWF)
+		wdSupply := types.NewAsset(0, Precision, SymbolW)
+		for _, a := range n.state.accounts {
+			supply, _ = supply.Add(a.balance(SymbolBWF, Precision))
+			wdSupply, _ = wdSupply.Add(a.balance(SymbolW, Precision))
+		}
+		return &api.DynamicGlobalProperties{
+			HeadBlockNumber:          head.Number,
+			HeadBlockID:              head.BlockId,
+			Time:                     head.Timestamp,
+			CurrentSupernode:         head.Supernode,
+			CurrentSupply:            supply,
+			CurrentWDSupply:          wdSupply,
+			TotalVestingFund:         types.NewAsset(0, Precision, SymbolBWF),
+			TotalVestingShares:       types.NewAsset(0, Precision, "M"),
+			CurrentAslot:             uint64(head.Number),
+			LastIrreversibleBlockNum: n.lastIrreversibleBlockNum(),
+		}, nil
+
+	case "get_block", "get_block_header":
+		var num uint32
+		if err := decodeArgs(args, &num); err != nil {
+			return nil, err
+		}
+		n.mutex.Lock()
+		defer n.mutex.Unlock()
+		block := n.block(num)
+		if block == nil {
+			return nil, nil
+		}
+		if method == "get_block_header" {
+			return blockHeader(block), nil
+		}
+		return block, nil
+
+	case "set_block_applied_callback":
+		if c == nil {
+			return nil, invalidParamsError(errors.New("callbacks need a websocket connection"))
+		}
+		var id uint64
+		if err := decodeArgs(args, &id); err != nil {
+			return nil, err
+		}
+		c.mutex.Lock()
+		c.callbacks = append(c.callbacks, id)
+		c.mutex.Unlock()
+		return nil, nil
+
+	case "get_accounts":
+		var names []string
+		if err := decodeArgs(args, &names); err != nil {
+			return nil, err
+		}
+		n.mutex.Lock()
+		defer n.mutex.Unlock()
+		accounts := api.AccountList{}
+		for _, name := range names {
+			if a, ok := n.pendingState.accounts[name]; ok {
+				accounts = append(accounts, a.accountInfo())
+			}
+		}
+		return accounts, nil
+
+	case "lookup_accounts":
+		var lowerBound string
+		var limit uint32
+		if err := decodeArgs(args, &lowerBound, &limit); err != nil {
+			return nil, err
+		}
+		n.mutex.Lock()
+		defer n.mutex.Unlock()
+		names := []string{}
+		for name := range n.pendingState.accounts {
+			if name >= lowerBound {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		if uint32(len(names)) > limit {
+			names = names[:limit]
+		}
+		return names, nil
+
+	case "get_account_count":
+		n.mutex.Lock()
+		defer n.mutex.Unlock()
+		return len(n.pendingState.accounts), nil
+
+	case "get_balance":
+		var name string
+		var symbol types.AssetSymbol
+		if err := decodeArgs(args, &name, &symbol); err != nil {
+			return nil, err
+		}
+		n.mutex.Lock()
+		defer n.mutex.Unlock()
+		a, err := n.pendingState.account(name)
+		if err != nil {
+			return nil, err
+		}
+		return a.balance(symbol.AssetName, symbol.Decimals).String(), nil
+
+	case "get_key_references":
+		var keys []string
+		if err := decodeArgs(args, &keys); err != nil {
+			return nil, err
+		}
+		n.mutex.Lock()
+		defer n.mutex.Unlock()
+		references := [][]string{}
+		for _, key := range keys {
+			references = append(references, n.keyReferences(key))
+		}
+		return references, nil
+
+	case "get_active_supernodes":
+		return []string{n.supernode}, nil
+
+	case "get_pending_transaction_count":
+		n.mutex.Lock()
+		defer n.mutex.Unlock()
+		return len(n.pending), nil
+
+	case "get_transaction", "get_transaction_with_status":
+		var id string
+		if err := decodeArgs(args, &id); err != nil {
+			return nil, err
+		}
+		n.mutex.Lock()
+		defer n.mutex.Unlock()
+		t, ok := n.transactions[id]
+		if method == "get_transaction" {
+			if !ok || t.blockNum == 0 {
+				return nil, assertError("false", "Unknown Transaction ${t}", "database_api.cpp", 1245, "get_transaction",
+					map[string]interface{}{"t": id})
+			}
+			return n.transactionResponse(t), nil
+		}
+		if !ok {
+			return &api.TransactionResponse{TransactionId: id, Status: api.TransactionStatusUnknown}, nil
+		}
+		resp := n.transactionResponse(t)
+		resp.Status = n.status(t)
+		return resp, nil
+
+	case "get_transaction_hex":
+		var raw json.RawMessage
+		if err := decodeArgs(args, &raw); err != nil {
+			return nil, err
+		}
+		tx, err := decodeTransaction(raw)
+		if err != nil {
+			return nil, err
+		}
+		data, serr := (&transactions.SignedTransaction{Transaction: tx}).Serialize()
+		if serr != nil {
+			return nil, invalidParamsError(serr)
+		}
+		return hex.EncodeToString(data), nil
+
+	case "broadcast_transaction", "broadcast_transaction_synchronous":
+		var raw json.RawMessage
+		if err := decodeArgs(args, &raw); err != nil {
+			return nil, err
+		}
+		tx, err := decodeTransaction(raw)
+		if err != nil {
+			return nil, err
+		}
+		t, err := n.push(tx)
+		if err != nil {
+			return nil, err
+		}
+		if method == "broadcast_transaction" {
+			return &api.AsyncBroadcastResponse{ID: t.id}, nil
+		}
+		return n.wait(ctx, t)
+	}
+	return nil, methodNotFoundError("condenser_api." + method)
+}
+
+// wait waits for the transaction to leave the pending transactions
+func (n *Node) wait(ctx context.Context, t *transaction) (interface{}, *rpcError) {
+	select {
+	case <-t.done:
+	case <-ctx.Done():
+		return nil, &rpcError{Code: codeRPCException, Message: ctx.Err().Error()}
+	case <-n.stop:
+		return nil, &rpcError{Code: codeRPCException, Message: "node is shutting down"}
+	}
+	if t.err != nil {
+		return nil, t.err
+	}
+	resp := &api.BroadcastResponse{
+		ID:          t.id,
+		BlockNum:    int32(t.blockNum),
+		TrxNum:      int32(t.trxNum),
+		Expired:     t.expired > 0,
+		CreatedTime: int64(t.tx.CreatedTime),
+	}
+	return resp, nil
+}
+
+func blockHeader(block *api.Block) api.BlockHeader {
+	return api.BlockHeader{
+		Number:                block.Number,
+		Previous:              block.Previous,
+		Timestamp:             block.Timestamp.Format(timeLayout),
+		Supernode:             block.Supernode,
+		TransactionMerkleRoot: block.TransactionMerkleRoot,
+		BlockReward:           block.BlockReward,
+		Extensions:            []interface{}{},
+	}
+}
@@ -0,0 +1,352 @@
+package mocknode
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/thanhxeon2470/beowulf-go/api"
+	"github.com/thanhxeon2470/beowulf-go/config"
+	"github.com/thanhxeon2470/beowulf-go/types"
+)
+
+//ContractHandler executes a sidechain transaction of a contract and returns its logs.
+//It is called for every smart contract operation of the contract included in a block,
+//e.g. to Insert the records the action creates.
+type ContractHandler func(tx *api.NFTTransaction) api.NFTLogs
+
+// sidechain holds the contract tables and the blocks of the sidechain
+type sidechain struct {
+	tables       map[string][]map[string]interface{}
+	lastIDs      map[string]float64
+	blocks       []*api.NFTBlock
+	transactions map[string]*api.NFTTransaction
+	handlers     map[string]ContractHandler
+}
+
+func newSidechain(genesis *api.Block) *sidechain {
+	return &sidechain{
+		tables:  make(map[string][]map[string]interface{}),
+		lastIDs: make(map[string]float64),
+		blocks: []*api.NFTBlock{{
+			RefBeowulfBlockNumber: genesis.Number,
+			RefBeowulfBlockId:     genesis.BlockId,
+			PrevRefBeowulfBlockId: genesis.Previous,
+			Timestamp:             genesis.Timestamp,
+			Transactions:          []*api.NFTTransaction{},
+			VirtualTransactions:   []*api.NFTTransaction{},
+		}},
+		transactions: make(map[string]*api.NFTTransaction),
+		handlers:     make(map[string]ContractHandler),
+	}
+}
+
+//Insert adds the records, structs or maps, to the table of the contract,
+//e.g. Insert("nft", "ARTinstances", instance). A record without an _id gets the next one of the table.
+func (n *Node) Insert(contract, table string, records ...interface{}) error {
+	decoded := make([]map[string]interface{}, 0, len(records))
+	for _, record := range records {
+		data, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		var fields map[string]interface{}
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return errors.Wrap(err, "a record must be a JSON object")
+		}
+		decoded = append(decoded, fields)
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	key := contract + "." + table
+	for _, fields := range decoded {
+		if id, ok := fields["_id"].(float64); ok {
+			if id > n.sidechain.lastIDs[key] {
+				n.sidechain.lastIDs[key] = id
+			}
+		} else {
+			n.sidechain.lastIDs[key]++
+			fields["_id"] = n.sidechain.lastIDs[key]
+		}
+		n.sidechain.tables[key] = append(n.sidechain.tables[key], fields)
+	}
+	return nil
+}
+
+//HandleContract sets the handler executing the sidechain transactions of the contract.
+//Without one the transactions are recorded with empty logs.
+func (n *Node) HandleContract(contract string, handler ContractHandler) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.sidechain.handlers[contract] = handler
+}
+
+// produceSidechainBlock executes the smart contract operations of the block in a sidechain block,
+// the handlers are called without the lock held
+func (n *Node) produceSidechainBlock(block *api.Block, included []*transaction) {
+	var txs []*api.NFTTransaction
+	for _, t := range included {
+		index := 0
+		for _, op := range t.tx.Operations {
+			sc, ok := op.(*types.SmartContractOperation)
+			if !ok {
+				continue
+			}
+			id := t.id
+			if index > 0 {
+				id = fmt.Sprintf("%s-%d", t.id, index)
+			}
+			index++
+			txs = append(txs, n.executeContract(block, id, sc))
+		}
+	}
+	if len(txs) == 0 {
+		return
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	previous := n.sidechain.blocks[len(n.sidechain.blocks)-1]
+	num := previous.BlockNumber + 1
+	n.sidechain.blocks = append(n.sidechain.blocks, &api.NFTBlock{
+		Id:                    num,
+		BlockNumber:           num,
+		RefBeowulfBlockNumber: block.Number,
+		RefBeowulfBlockId:     block.BlockId,
+		PrevRefBeowulfBlockId: block.Previous,
+		PreviousHash:          previous.Hash,
+		Timestamp:             block.Timestamp,
+		Transactions:          txs,
+		VirtualTransactions:   []*api.NFTTransaction{},
+		Hash:                  block.TransactionMerkleRoot,
+		Supernode:             block.Supernode,
+	})
+	for _, tx := range txs {
+		n.sidechain.transactions[tx.TransactionId] = tx
+	}
+}
+
+func (n *Node) executeContract(block *api.Block, id string, op *types.SmartContractOperation) *api.NFTTransaction {
+	refBlockNum := types.UInt32(block.Number)
+	tx := &api.NFTTransaction{RefBeowulfBlockNumber: &refBlockNum, TransactionId: id, Logs: "{}"}
+	if len(op.RequiredOwners) > 0 {
+		tx.Sender = op.RequiredOwners[0]
+	}
+	var call struct {
+		ContractName    string          `json:"contractName"`
+		ContractAction  string          `json:"contractAction"`
+		ContractPayload json.RawMessage `json:"contractPayload"`
+	}
+	if err := json.Unmarshal([]byte(op.ScOperation), &call); err != nil {
+		logs, _ := json.Marshal(api.NFTLogs{Errors: []string{"invalid sc_operation: " + err.Error()}})
+		tx.Logs = string(logs)
+		return tx
+	}
+	tx.Contract, tx.Action, tx.Payload = call.ContractName, call.ContractAction, string(call.ContractPayload)
+
+	n.mutex.Lock()
+	handler := n.sidechain.handlers[call.ContractName]
+	n.mutex.Unlock()
+	if handler != nil {
+		logs, _ := json.Marshal(handler(tx))
+		tx.Logs = string(logs)
+	}
+	return tx
+}
+
+// callSidechain answers the methods of the sidechain RPC
+func (n *Node) callSidechain(method string, params json.RawMessage) (interface{}, *rpcError) {
+	switch method {
+	case "find", "findOne":
+		var p api.Params
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, invalidParamsError(err)
+		}
+		query, ok := p.Query.(map[string]interface{})
+		if p.Query != nil && !ok {
+			return nil, invalidParamsError(errors.New("query must be an object"))
+		}
+		n.mutex.Lock()
+		defer n.mutex.Unlock()
+		matching := []map[string]interface{}{}
+		for _, record := range n.sidechain.tables[p.Contract+"."+p.Table] {
+			if matches(record, query) {
+				matching = append(matching, record)
+			}
+		}
+		if method == "findOne" {
+			if len(matching) == 0 {
+				return nil, nil
+			}
+			return matching[0], nil
+		}
+		sortRecords(matching, p.Indexes)
+		limit := p.Limit
+		if limit == 0 || limit > config.SIDECHAIN_FIND_PAGE_SIZE {
+			limit = config.SIDECHAIN_FIND_PAGE_SIZE
+		}
+		if int(p.Offset) >= len(matching) {
+			return []map[string]interface{}{}, nil
+		}
+		matching = matching[p.Offset:]
+		if uint32(len(matching)) > limit {
+			matching = matching[:limit]
+		}
+		return matching, nil
+
+	case "getLatestBlockInfo":
+		n.mutex.Lock()
+		defer n.mutex.Unlock()
+		return n.sidechain.blocks[len(n.sidechain.blocks)-1], nil
+
+	case "getBlockInfo":
+		var p api.BlockParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, invalidParamsError(err)
+		}
+		n.mutex.Lock()
+		defer n.mutex.Unlock()
+		if int(p.BlockNumber) >= len(n.sidechain.blocks) {
+			return nil, nil
+		}
+		return n.sidechain.blocks[p.BlockNumber], nil
+
+	case "getTransactionInfo":
+		var p api.TransactionParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, invalidParamsError(err)
+		}
+		n.mutex.Lock()
+		defer n.mutex.Unlock()
+		tx, ok := n.sidechain.transactions[p.Txid]
+		if !ok {
+			return nil, nil
+		}
+		return tx, nil
+	}
+	return nil, methodNotFoundError(method)
+}
+
+// field returns the value of the field of the record, nested fields are separated by dots
+func field(record map[string]interface{}, name string) interface{} {
+	var value interface{} = record
+	for _, part := range strings.Split(name, ".") {
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = fields[part]
+	}
+	return value
+}
+
+// isOperators tells whether the condition is an object of query operators like {"$in": [...]}
+func isOperators(condition interface{}) (map[string]interface{}, bool) {
+	ops, ok := condition.(map[string]interface{})
+	if !ok || len(ops) == 0 {
+		return nil, false
+	}
+	for op := range ops {
+		if !strings.HasPrefix(op, "$") {
+			return nil, false
+		}
+	}
+	return ops, true
+}
+
+// matches tells whether the record matches the query, both decoded from JSON
+func matches(record map[string]interface{}, query map[string]interface{}) bool {
+	for name, condition := range query {
+		value := field(record, name)
+		ops, ok := isOperators(condition)
+		if !ok {
+			if !reflect.DeepEqual(value, condition) {
+				return false
+			}
+			continue
+		}
+		for op, arg := range ops {
+			if !matchesOperator(value, op, arg) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func matchesOperator(value interface{}, op string, arg interface{}) bool {
+	switch op {
+	case "$eq":
+		return reflect.DeepEqual(value, arg)
+	case "$ne":
+		return !reflect.DeepEqual(value, arg)
+	case "$in", "$nin":
+		values, _ := arg.([]interface{})
+		found := false
+		for _, v := range values {
+			found = found || reflect.DeepEqual(value, v)
+		}
+		return found == (op == "$in")
+	case "$gt", "$gte", "$lt", "$lte":
+		cmp, ok := compare(value, arg)
+		if !ok {
+			return false
+		}
+		switch op {
+		case "$gt":
+			return cmp > 0
+		case "$gte":
+			return cmp >= 0
+		case "$lt":
+			return cmp < 0
+		}
+		return cmp <= 0
+	}
+	return false
+}
+
+// compare compares two numbers or two strings
+func compare(a, b interface{}) (int, bool) {
+	switch a := a.(type) {
+	case float64:
+		b, ok := b.(float64)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case a < b:
+			return -1, true
+		case a > b:
+			return 1, true
+		}
+		return 0, true
+	case string:
+		b, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(a, b), true
+	}
+	return 0, false
+}
+
+// sortRecords sorts the records by the indexes, in the order of insertion without one
+func sortRecords(records []map[string]interface{}, indexes []api.Index) {
+	sort.SliceStable(records, func(i, j int) bool {
+		for _, index := range indexes {
+			cmp, _ := compare(field(records[i], index.Index), field(records[j], index.Index))
+			if cmp == 0 {
+				continue
+			}
+			if index.Descending {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	})
+}
@@ -0,0 +1,254 @@
+package mocknode
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/thanhxeon2470/beowulf-go/api"
+	"github.com/thanhxeon2470/beowulf-go/config"
+	"github.com/thanhxeon2470/beowulf-go/transactions"
+	"github.com/thanhxeon2470/beowulf-go/types"
+)
+
+// The native symbols and their precision
+const (
+	SymbolBWF = "BWF"
+	SymbolW   = config.WD_SYMBOL
+	Precision = 5
+)
+
+type account struct {
+	info     api.AccountInfo
+	balances map[string]*types.Asset
+}
+
+// state holds the accounts as of a block, or of the pending transactions
+type state struct {
+	accounts map[string]*account
+}
+
+func newState() *state {
+	return &state{accounts: make(map[string]*account)}
+}
+
+func (s *state) clone() *state {
+	c := newState()
+	for name, a := range s.accounts {
+		balances := make(map[string]*types.Asset, len(a.balances))
+		for symbol, balance := range a.balances {
+			b := *balance
+			balances[symbol] = &b
+		}
+		c.accounts[name] = &account{info: a.info, balances: balances}
+	}
+	return c
+}
+
+func (s *state) account(name string) (*account, *rpcError) {
+	a, ok := s.accounts[name]
+	if !ok {
+		return nil, unknownAccountError(name)
+	}
+	return a, nil
+}
+
+// balance returns the balance of the symbol, zero with the precision of amount when there is none
+func (a *account) balance(symbol string, precision uint8) *types.Asset {
+	if balance, ok := a.balances[symbol]; ok {
+		return balance
+	}
+	return types.NewAsset(0, precision, symbol)
+}
+
+// accountInfo returns the account as get_accounts answers it
+func (a *account) accountInfo() api.AccountInfo {
+	info := a.info
+	info.Balance = a.balance(SymbolBWF, Precision).String()
+	info.WdBalance = a.balance(SymbolW, Precision).String()
+	info.VestingShares = types.NewAsset(0, Precision, "M").String()
+	info.TokenList = []string{}
+	for symbol := range a.balances {
+		if symbol != SymbolBWF && symbol != SymbolW {
+			info.TokenList = append(info.TokenList, symbol)
+		}
+	}
+	sort.Strings(info.TokenList)
+	return info
+}
+
+func (s *state) lookup(name string) (*api.AccountInfo, error) {
+	a, err := s.account(name)
+	if err != nil {
+		return nil, err
+	}
+	info := a.accountInfo()
+	return &info, nil
+}
+
+func (s *state) credit(a *account, amount *types.Asset) *rpcError {
+	sum, err := a.balance(amount.Symbol, amount.Precision).Add(amount)
+	if err != nil {
+		return assertError("balance + amount", err.Error(), "database.cpp", 1180, "adjust_balance", nil)
+	}
+	a.balances[amount.Symbol] = sum
+	return nil
+}
+
+func (s *state) debit(a *account, amount *types.Asset, message string) *rpcError {
+	balance := a.balance(amount.Symbol, amount.Precision)
+	cmp, err := balance.Cmp(amount)
+	if err != nil || cmp < 0 {
+		return assertError("_db.get_balance( from_account, o.amount.symbol ) >= o.amount", message,
+			"beowulf_evaluator.cpp", 231, "do_apply", map[string]interface{}{"balance": balance.String(), "amount": amount.String()})
+	}
+	rest, _ := balance.Sub(amount)
+	a.balances[amount.Symbol] = rest
+	return nil
+}
+
+// payFee moves the fee of an operation from the payer to the supernode producing the blocks
+func (s *state) payFee(payer string, fee *types.Asset, minFee float64, supernode string) *rpcError {
+	if fee == nil || fee.Symbol != SymbolW || fee.Float64() < minFee {
+		return assertError("o.fee >= min_fee", "Fee is not enough", "beowulf_operations.cpp", 40, "validate",
+			map[string]interface{}{"fee": fmt.Sprint(fee)})
+	}
+	a, err := s.account(payer)
+	if err != nil {
+		return err
+	}
+	if err := s.debit(a, fee, "Account does not have sufficient funds for fee."); err != nil {
+		return err
+	}
+	if producer, ok := s.accounts[supernode]; ok {
+		return s.credit(producer, fee)
+	}
+	return nil
+}
+
+// requiredOwners returns the accounts whose owner authority must sign the operation
+func requiredOwners(op types.Operation) []string {
+	switch op := op.(type) {
+	case *types.TransferOperation:
+		return []string{op.From}
+	case *types.AccountCreateOperation:
+		return []string{op.Creator}
+	case *types.AccountUpdateOperation:
+		return []string{op.Account}
+	case *types.AccountSupernodeVoteOperation:
+		return []string{op.Account}
+	case *types.SupernodeUpdateOperation:
+		return []string{op.Owner}
+	case *types.TransferToVestingOperation:
+		return []string{op.From}
+	case *types.WithdrawVestingOperation:
+		return []string{op.Account}
+	case *types.SmtCreateOperation:
+		return []string{op.Creator}
+	case *types.CheckSidechainOperation:
+		return []string{op.Committer}
+	case *types.SmartContractOperation:
+		return op.RequiredOwners
+	}
+	return nil
+}
+
+// verifyAuthority checks the signing keys satisfy the owner authority of every required account
+func (s *state) verifyAuthority(tx *types.Transaction, chainID string) *rpcError {
+	keys, err := (&transactions.SignedTransaction{Transaction: tx}).Verify(chainID)
+	if err != nil {
+		return assertError("sigs.size() > 0", err.Error(), "transaction.cpp", 168, "get_signature_keys", nil)
+	}
+	for _, op := range tx.Operations {
+		owners := requiredOwners(op)
+		if len(owners) == 0 {
+			return assertError("false", "Unsupported operation ${type}", "operation_util_impl.hpp", 72, "validate",
+				map[string]interface{}{"type": string(op.Type())})
+		}
+		for _, name := range owners {
+			a, rerr := s.lookup(name)
+			if rerr != nil {
+				return unknownAccountError(name)
+			}
+			if err := transactions.VerifyOwner(keys, a, s.lookup); err != nil {
+				return missingOwnerAuthError(name)
+			}
+		}
+	}
+	return nil
+}
+
+// apply applies the operations of the transaction to the state, supernode gets the fees
+func (s *state) apply(tx *types.Transaction, supernode string) *rpcError {
+	for _, op := range tx.Operations {
+		if err := s.applyOperation(op, supernode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *state) applyOperation(op types.Operation, supernode string) *rpcError {
+	switch op := op.(type) {
+	case *types.TransferOperation:
+		from, err := s.account(op.From)
+		if err != nil {
+			return err
+		}
+		to, err := s.account(op.To)
+		if err != nil {
+			return err
+		}
+		if op.Amount == nil || op.Amount.Sign() <= 0 {
+			return assertError("amount.amount > 0", "Cannot transfer a negative amount (aka: stealing)",
+				"beowulf_operations.cpp", 60, "validate", nil)
+		}
+		if err := s.payFee(op.From, op.Fee, config.MIN_TRANSACTION_FEE, supernode); err != nil {
+			return err
+		}
+		if err := s.debit(from, op.Amount, "Account does not have sufficient funds for transfer."); err != nil {
+			return err
+		}
+		return s.credit(to, op.Amount)
+
+	case *types.AccountCreateOperation:
+		if _, ok := s.accounts[op.NewAccountName]; ok {
+			return assertError("account == nullptr", "Account ${name} already exists.", "beowulf_evaluator.cpp", 95,
+				"do_apply", map[string]interface{}{"name": op.NewAccountName})
+		}
+		if err := s.payFee(op.Creator, op.Fee, config.MIN_ACCOUNT_CREATION_FEE, supernode); err != nil {
+			return err
+		}
+		s.accounts[op.NewAccountName] = &account{
+			info:     api.AccountInfo{Name: op.NewAccountName, Owner: op.Owner},
+			balances: make(map[string]*types.Asset),
+		}
+		return nil
+
+	case *types.AccountUpdateOperation:
+		a, err := s.account(op.Account)
+		if err != nil {
+			return err
+		}
+		if err := s.payFee(op.Account, op.Fee, config.MIN_TRANSACTION_FEE, supernode); err != nil {
+			return err
+		}
+		if op.Owner != nil {
+			a.info.Owner = op.Owner
+		}
+		return nil
+
+	case *types.AccountSupernodeVoteOperation:
+		return s.payFee(op.Account, op.Fee, config.MIN_TRANSACTION_FEE, supernode)
+	case *types.SupernodeUpdateOperation:
+		return s.payFee(op.Owner, op.Fee, config.MIN_TRANSACTION_FEE, supernode)
+	case *types.TransferToVestingOperation:
+		return s.payFee(op.From, op.Fee, config.MIN_TRANSACTION_FEE, supernode)
+	case *types.WithdrawVestingOperation:
+		return s.payFee(op.Account, op.Fee, config.MIN_TRANSACTION_FEE, supernode)
+	case *types.CheckSidechainOperation:
+		return s.payFee(op.Committer, op.Fee, config.MIN_TRANSACTION_FEE, supernode)
+	case *types.SmartContractOperation:
+		return s.payFee(op.RequiredOwners[0], op.Fee, config.MIN_TRANSACTION_FEE, supernode)
+	}
+	return nil
+}
@@ -0,0 +1,343 @@
+package nft
+
+import (
+	// Vendor
+	"github.com/pkg/errors"
+)
+
+// The actions of the nft contract
+const (
+	ActionCreate                          = "create"
+	ActionUpdateMetadata                  = "updateMetadata"
+	ActionUpdateName                      = "updateName"
+	ActionUpdateOrgName                   = "updateOrgName"
+	ActionAddProperty                     = "addProperty"
+	ActionUpdatePropertyDefinition        = "updatePropertyDefinition"
+	ActionIssue                           = "issue"
+	ActionIssueMultiple                   = "issueMultiple"
+	ActionTransfer                        = "transfer"
+	ActionBurn                            = "burn"
+	ActionSetProperties                   = "setProperties"
+	ActionAddAuthorizedIssuingAccounts    = "addAuthorizedIssuingAccounts"
+	ActionRemoveAuthorizedIssuingAccounts = "removeAuthorizedIssuingAccounts"
+)
+
+//Create creates a new NFT symbol.
+type Create struct {
+	Name                      string   `json:"name"`
+	Symbol                    string   `json:"symbol"`
+	MaxSupply                 string   `json:"maxSupply,omitempty"`
+	AuthorizedIssuingAccounts []string `json:"authorizedIssuingAccounts,omitempty"`
+}
+
+func (p *Create) Action() string { return ActionCreate }
+
+func (p *Create) Validate() error {
+	if err := validateName("name", p.Name, MaxNameLength); err != nil {
+		return err
+	}
+	if err := ValidateSymbol(p.Symbol); err != nil {
+		return err
+	}
+	if p.MaxSupply != "" {
+		if err := validateSupply(p.MaxSupply); err != nil {
+			return err
+		}
+	}
+	return validateAccounts(p.AuthorizedIssuingAccounts, true)
+}
+
+//Metadata is the metadata of an NFT symbol.
+type Metadata struct {
+	URL   string `json:"url"`
+	Image string `json:"image"`
+}
+
+//UpdateMetadata replaces the metadata of a symbol.
+type UpdateMetadata struct {
+	Symbol   string   `json:"symbol"`
+	Metadata Metadata `json:"metadata"`
+}
+
+func (p *UpdateMetadata) Action() string { return ActionUpdateMetadata }
+
+func (p *UpdateMetadata) Validate() error {
+	return ValidateSymbol(p.Symbol)
+}
+
+//UpdateName renames a symbol.
+type UpdateName struct {
+	Symbol string `json:"symbol"`
+	Name   string `json:"name"`
+}
+
+func (p *UpdateName) Action() string { return ActionUpdateName }
+
+func (p *UpdateName) Validate() error {
+	if err := ValidateSymbol(p.Symbol); err != nil {
+		return err
+	}
+	return validateName("name", p.Name, MaxNameLength)
+}
+
+//UpdateOrgName sets the organization name of a symbol.
+type UpdateOrgName struct {
+	Symbol  string `json:"symbol"`
+	OrgName string `json:"orgName"`
+}
+
+func (p *UpdateOrgName) Action() string { return ActionUpdateOrgName }
+
+func (p *UpdateOrgName) Validate() error {
+	if err := ValidateSymbol(p.Symbol); err != nil {
+		return err
+	}
+	return validateName("organization name", p.OrgName, MaxNameLength)
+}
+
+//AddProperty adds a property to the instances of a symbol.
+type AddProperty struct {
+	Symbol                    string       `json:"symbol"`
+	Name                      string       `json:"name"`
+	Type                      PropertyType `json:"type"`
+	AuthorizedEditingAccounts []string     `json:"authorizedEditingAccounts,omitempty"`
+}
+
+func (p *AddProperty) Action() string { return ActionAddProperty }
+
+func (p *AddProperty) Validate() error {
+	if err := ValidateSymbol(p.Symbol); err != nil {
+		return err
+	}
+	if err := validatePropertyName(p.Name); err != nil {
+		return err
+	}
+	if err := ValidatePropertyType(p.Type); err != nil {
+		return err
+	}
+	return validateAccounts(p.AuthorizedEditingAccounts, true)
+}
+
+//UpdatePropertyDefinition renames a property and changes its type.
+type UpdatePropertyDefinition struct {
+	Symbol  string       `json:"symbol"`
+	Name    string       `json:"name"`
+	Type    PropertyType `json:"type"`
+	NewName string       `json:"newName"`
+}
+
+func (p *UpdatePropertyDefinition) Action() string { return ActionUpdatePropertyDefinition }
+
+func (p *UpdatePropertyDefinition) Validate() error {
+	if err := ValidateSymbol(p.Symbol); err != nil {
+		return err
+	}
+	if err := validatePropertyName(p.Name); err != nil {
+		return err
+	}
+	if err := validatePropertyName(p.NewName); err != nil {
+		return err
+	}
+	return ValidatePropertyType(p.Type)
+}
+
+//Issue issues a new instance of a symbol.
+type Issue struct {
+	Symbol     string      `json:"symbol"`
+	To         string      `json:"to"`
+	ToType     string      `json:"toType"`
+	FeeSymbol  string      `json:"feeSymbol"`
+	Properties interface{} `json:"properties,omitempty"`
+}
+
+//NewIssue returns the issue of an instance of the symbol to a user paid in DefaultFeeSymbol.
+func NewIssue(symbol, to string) *Issue {
+	return &Issue{
+		Symbol:    symbol,
+		To:        to,
+		ToType:    ToTypeUser,
+		FeeSymbol: DefaultFeeSymbol,
+	}
+}
+
+func (p *Issue) Action() string { return ActionIssue }
+
+func (p *Issue) Validate() error {
+	if err := ValidateSymbol(p.Symbol); err != nil {
+		return err
+	}
+	if err := ValidateSymbol(p.FeeSymbol); err != nil {
+		return errors.Wrap(err, "fee symbol")
+	}
+	switch p.ToType {
+	case ToTypeUser:
+		return validateAccount(p.To)
+	case ToTypeContract:
+		return validateName("recipient", p.To, MaxNameLength)
+	}
+	return errors.Errorf("recipient type %q must be user or contract", p.ToType)
+}
+
+//IssueMultiple issues several instances at once.
+type IssueMultiple struct {
+	Instances []Issue `json:"instances"`
+}
+
+func (p *IssueMultiple) Action() string { return ActionIssueMultiple }
+
+func (p *IssueMultiple) Validate() error {
+	if len(p.Instances) == 0 {
+		return errors.New("no instance to issue")
+	}
+	if len(p.Instances) > MaxInstances {
+		return errors.Errorf("at most %d instances can be issued at once", MaxInstances)
+	}
+	for i := range p.Instances {
+		if err := p.Instances[i].Validate(); err != nil {
+			return errors.Wrapf(err, "instance %d", i)
+		}
+	}
+	return nil
+}
+
+//InstanceIDs are the ids of instances of a symbol.
+type InstanceIDs struct {
+	Symbol string   `json:"symbol"`
+	Ids    []string `json:"ids"`
+}
+
+func validateInstanceIDs(nfts []InstanceIDs) error {
+	if len(nfts) == 0 {
+		return errors.New("no instance given")
+	}
+	count := 0
+	for _, item := range nfts {
+		if err := ValidateSymbol(item.Symbol); err != nil {
+			return err
+		}
+		if len(item.Ids) == 0 {
+			return errors.Errorf("no instance of %s given", item.Symbol)
+		}
+		for _, id := range item.Ids {
+			if id == "" {
+				return errors.Errorf("empty instance id of %s", item.Symbol)
+			}
+		}
+		count += len(item.Ids)
+	}
+	if count > MaxInstances {
+		return errors.Errorf("at most %d instances can be given at once", MaxInstances)
+	}
+	return nil
+}
+
+//Transfer transfers instances to another account.
+type Transfer struct {
+	To   string        `json:"to"`
+	Nfts []InstanceIDs `json:"nfts"`
+}
+
+func (p *Transfer) Action() string { return ActionTransfer }
+
+func (p *Transfer) Validate() error {
+	if err := validateAccount(p.To); err != nil {
+		return errors.Wrap(err, "recipient")
+	}
+	return validateInstanceIDs(p.Nfts)
+}
+
+//Burn burns instances.
+type Burn struct {
+	Nfts []InstanceIDs `json:"nfts"`
+}
+
+func (p *Burn) Action() string { return ActionBurn }
+
+func (p *Burn) Validate() error {
+	return validateInstanceIDs(p.Nfts)
+}
+
+//PropertyValue is the value of a property of an instance.
+type PropertyValue struct {
+	Name string      `json:"name"`
+	Data interface{} `json:"data"`
+}
+
+//InstanceProperties sets a property of an instance.
+type InstanceProperties struct {
+	Properties PropertyValue `json:"properties"`
+	Id         string        `json:"id"`
+}
+
+//SetProperties sets properties of instances of a symbol.
+type SetProperties struct {
+	Symbol string               `json:"symbol"`
+	Nfts   []InstanceProperties `json:"nfts"`
+}
+
+func (p *SetProperties) Action() string { return ActionSetProperties }
+
+func (p *SetProperties) Validate() error {
+	if err := ValidateSymbol(p.Symbol); err != nil {
+		return err
+	}
+	if len(p.Nfts) == 0 {
+		return errors.New("no property to set")
+	}
+	if len(p.Nfts) > MaxInstances {
+		return errors.Errorf("at most %d instances can be given at once", MaxInstances)
+	}
+	for _, item := range p.Nfts {
+		if item.Id == "" {
+			return errors.New("empty instance id")
+		}
+		if err := validatePropertyName(item.Properties.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//AddAuthorizedIssuingAccounts allows accounts to issue instances of a symbol.
+type AddAuthorizedIssuingAccounts struct {
+	Symbol   string   `json:"symbol"`
+	Accounts []string `json:"accounts"`
+}
+
+func (p *AddAuthorizedIssuingAccounts) Action() string { return ActionAddAuthorizedIssuingAccounts }
+
+func (p *AddAuthorizedIssuingAccounts) Validate() error {
+	if err := ValidateSymbol(p.Symbol); err != nil {
+		return err
+	}
+	return validateAccounts(p.Accounts, false)
+}
+
+//RemoveAuthorizedIssuingAccounts revokes the right of accounts to issue instances of a symbol.
+type RemoveAuthorizedIssuingAccounts struct {
+	Symbol   string   `json:"symbol"`
+	Accounts []string `json:"accounts"`
+}
+
+func (p *RemoveAuthorizedIssuingAccounts) Action() string {
+	return ActionRemoveAuthorizedIssuingAccounts
+}
+
+func (p *RemoveAuthorizedIssuingAccounts) Validate() error {
+	if err := ValidateSymbol(p.Symbol); err != nil {
+		return err
+	}
+	return validateAccounts(p.Accounts, false)
+}
+
+func validateSupply(supply string) error {
+	for _, c := range supply {
+		if c < '0' || c > '9' {
+			return errors.Errorf("max supply %q must be a positive integer", supply)
+		}
+	}
+	if len(supply) > 1 && supply[0] == '0' || supply == "0" {
+		return errors.Errorf("max supply %q must be a positive integer", supply)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+//Package nft builds the operations calling the nft contract of the sidechain.
+package nft
+
+import (
+	// Stdlib
+	"encoding/json"
+	"strings"
+
+	// RPC
+	"github.com/thanhxeon2470/beowulf-go/config"
+	"github.com/thanhxeon2470/beowulf-go/types"
+
+	// Vendor
+	"github.com/pkg/errors"
+)
+
+const (
+	//ContractName is the name of the nft contract
+	ContractName = "nft"
+	//DefaultScid is the sidechain used when no scid is given
+	DefaultScid = config.SIDECHAIN_ID
+
+	ToTypeUser       = "user"
+	ToTypeContract   = "contract"
+	DefaultFeeSymbol = "BEE"
+)
+
+// The limits enforced by the contract
+const (
+	MaxSymbolLength       = 10
+	MaxNameLength         = 50
+	MaxPropertyNameLength = 25
+	MaxAuthorizedAccounts = 10
+	MaxInstances          = 50
+)
+
+//PropertyType is the data type of an NFT property
+type PropertyType string
+
+const (
+	PropertyNumber  PropertyType = "number"
+	PropertyString  PropertyType = "string"
+	PropertyBoolean PropertyType = "boolean"
+)
+
+//Payload is the contractPayload of an action of the nft contract
+type Payload interface {
+	//Action returns the contractAction the payload is sent with.
+	Action() string
+	//Validate checks the payload against the rules of the contract.
+	Validate() error
+}
+
+type scOperation struct {
+	ContractName    string  `json:"contractName"`
+	ContractAction  string  `json:"contractAction"`
+	ContractPayload Payload `json:"contractPayload"`
+}
+
+//ScOperation validates the payload and returns the sc_operation calling its action.
+func ScOperation(payload Payload) (string, error) {
+	if err := payload.Validate(); err != nil {
+		return "", errors.Wrap(err, payload.Action())
+	}
+	data, err := json.Marshal(scOperation{
+		ContractName:    ContractName,
+		ContractAction:  payload.Action(),
+		ContractPayload: payload,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+//NewOperation returns the operation calling the action of the payload, signed by the owners.
+//Several operations can be sent in one transaction.
+func NewOperation(scid string, fee *types.Asset, payload Payload, owners ...string) (*types.SmartContractOperation, error) {
+	if len(owners) == 0 {
+		return nil, errors.New("the operation has no required owner")
+	}
+	for _, owner := range owners {
+		if err := validateAccount(owner); err != nil {
+			return nil, err
+		}
+	}
+	scOp, err := ScOperation(payload)
+	if err != nil {
+		return nil, err
+	}
+	if scid == "" {
+		scid = DefaultScid
+	}
+	return &types.SmartContractOperation{
+		RequiredOwners: owners,
+		Scid:           scid,
+		ScOperation:    scOp,
+		Fee:            fee,
+	}, nil
+}
+
+//ValidateSymbol checks that the symbol has 1 to MaxSymbolLength uppercase letters.
+func ValidateSymbol(symbol string) error {
+	if len(symbol) == 0 || len(symbol) > MaxSymbolLength {
+		return errors.Errorf("symbol %q must have 1 to %d letters", symbol, MaxSymbolLength)
+	}
+	for _, c := range symbol {
+		if c < 'A' || c > 'Z' {
+			return errors.Errorf("symbol %q must have uppercase letters only", symbol)
+		}
+	}
+	return nil
+}
+
+//ValidatePropertyType checks that the type is one of the property types of the contract.
+func ValidatePropertyType(t PropertyType) error {
+	switch t {
+	case PropertyNumber, PropertyString, PropertyBoolean:
+		return nil
+	}
+	return errors.Errorf("property type %q must be number, string or boolean", string(t))
+}
+
+func validateName(what, name string, maxLength int) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.Errorf("%s is empty", what)
+	}
+	if len(name) > maxLength {
+		return errors.Errorf("%s %q is longer than %d characters", what, name, maxLength)
+	}
+	return nil
+}
+
+func validatePropertyName(name string) error {
+	if err := validateName("property name", name, MaxPropertyNameLength); err != nil {
+		return err
+	}
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+			return errors.Errorf("property name %q must have letters and digits only", name)
+		}
+	}
+	return nil
+}
+
+func validateAccount(name string) error {
+	if len(name) < 3 || len(name) > 16 {
+		return errors.Errorf("account %q must have 3 to 16 characters", name)
+	}
+	for _, c := range name {
+		if !strings.ContainsRune(config.NAME_LETTER, c) {
+			return errors.Errorf("account %q has an invalid character", name)
+		}
+	}
+	return nil
+}
+
+func validateAccounts(accounts []string, allowEmpty bool) error {
+	if len(accounts) == 0 && !allowEmpty {
+		return errors.New("no account given")
+	}
+	if len(accounts) > MaxAuthorizedAccounts {
+		return errors.Errorf("at most %d accounts can be given", MaxAuthorizedAccounts)
+	}
+	for _, account := range accounts {
+		if err := validateAccount(account); err != nil {
+			return err
+		}
+	}
+	return nil
+}
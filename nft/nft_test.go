@@ -0,0 +1,97 @@
+package nft
+
+import (
+	"testing"
+
+	"github.com/thanhxeon2470/beowulf-go/types"
+)
+
+func TestScOperation(t *testing.T) {
+	cases := []struct {
+		payload Payload
+		want    string
+	}{
+		{
+			&Create{Name: `My "quoted" art`, Symbol: "ART", MaxSupply: "100"},
+			`{"contractName":"nft","contractAction":"create","contractPayload":{"name":"My \"quoted\" art","symbol":"ART","maxSupply":"100"}}`,
+		},
+		{
+			&AddProperty{Symbol: "ART", Name: "color", Type: PropertyString, AuthorizedEditingAccounts: []string{"alice"}},
+			`{"contractName":"nft","contractAction":"addProperty","contractPayload":{"symbol":"ART","name":"color","type":"string","authorizedEditingAccounts":["alice"]}}`,
+		},
+		{
+			NewIssue("ART", "bob"),
+			`{"contractName":"nft","contractAction":"issue","contractPayload":{"symbol":"ART","to":"bob","toType":"user","feeSymbol":"BEE"}}`,
+		},
+		{
+			&Transfer{To: "carol", Nfts: []InstanceIDs{{Symbol: "ART", Ids: []string{"1", "2"}}}},
+			`{"contractName":"nft","contractAction":"transfer","contractPayload":{"to":"carol","nfts":[{"symbol":"ART","ids":["1","2"]}]}}`,
+		},
+		{
+			&SetProperties{Symbol: "ART", Nfts: []InstanceProperties{{Properties: PropertyValue{Name: "color", Data: "red"}, Id: "1"}}},
+			`{"contractName":"nft","contractAction":"setProperties","contractPayload":{"symbol":"ART","nfts":[{"properties":{"name":"color","data":"red"},"id":"1"}]}}`,
+		},
+	}
+	for _, c := range cases {
+		got, err := ScOperation(c.payload)
+		if err != nil {
+			t.Errorf("%s: %v", c.payload.Action(), err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("%s:\n got %s\nwant %s", c.payload.Action(), got, c.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	ids := make([]string, MaxInstances+1)
+	for i := range ids {
+		ids[i] = "1"
+	}
+	invalid := []Payload{
+		&Create{Name: "art", Symbol: "art"},
+		&Create{Name: "art", Symbol: "TOOLONGSYMBOL"},
+		&Create{Name: "", Symbol: "ART"},
+		&Create{Name: "art", Symbol: "ART", MaxSupply: "0"},
+		&Create{Name: "art", Symbol: "ART", MaxSupply: "-1"},
+		&Create{Name: "art", Symbol: "ART", AuthorizedIssuingAccounts: []string{"Alice"}},
+		&AddProperty{Symbol: "ART", Name: "color", Type: "date"},
+		&AddProperty{Symbol: "ART", Name: "the color", Type: PropertyString},
+		&Issue{Symbol: "ART", To: "bob", ToType: "group", FeeSymbol: DefaultFeeSymbol},
+		&IssueMultiple{},
+		&Transfer{To: "carol"},
+		&Burn{Nfts: []InstanceIDs{{Symbol: "ART", Ids: ids}}},
+		&AddAuthorizedIssuingAccounts{Symbol: "ART"},
+	}
+	for _, payload := range invalid {
+		if _, err := ScOperation(payload); err == nil {
+			t.Errorf("%s %+v: no error", payload.Action(), payload)
+		}
+	}
+}
+
+func TestNewOperation(t *testing.T) {
+	fee := types.MustParseAsset("0.01000 W")
+	var ops types.Operations
+	for _, payload := range []Payload{
+		&Create{Name: "art", Symbol: "ART"},
+		&AddProperty{Symbol: "ART", Name: "color", Type: PropertyString},
+		NewIssue("ART", "alice"),
+	} {
+		op, err := NewOperation("", fee, payload, "alice")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if op.Scid != DefaultScid {
+			t.Errorf("scid %q, want %q", op.Scid, DefaultScid)
+		}
+		ops = append(ops, op)
+	}
+	if len(ops) != 3 {
+		t.Errorf("%d operations", len(ops))
+	}
+	if _, err := NewOperation("s01", fee, NewIssue("ART", "alice")); err == nil {
+		t.Error("no error without owner")
+	}
+}
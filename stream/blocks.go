@@ -0,0 +1,198 @@
+package stream
+
+import (
+	"context"
+	"time"
+
+	"github.com/thanhxeon2470/beowulf-go/api"
+)
+
+const (
+	defaultPollInterval = time.Second
+	defaultRetryDelay   = 3 * time.Second
+	defaultBatchSize    = 20
+)
+
+//BlockStream reads the chain block by block from a given number, it catches up with
+//batched GetBlock calls and then polls for new blocks. Only the number of the next
+//block is kept, so a failed call or a reconnect of the transport never skips or repeats
+//a block. Head blocks can still be reverted by a fork, use WithIrreversibleOnly when
+//that matters.
+type BlockStream struct {
+	api  *api.API
+	next uint32
+
+	irreversible bool
+	pollInterval time.Duration
+	retryDelay   time.Duration
+	batchSize    uint32
+	onError      func(err error)
+
+	buffer []*api.Block
+}
+
+//Option configures the BlockStream created by NewBlockStream
+type Option func(*BlockStream)
+
+//WithIrreversibleOnly emits only the blocks at or below LastIrreversibleBlockNum.
+func WithIrreversibleOnly() Option {
+	return func(s *BlockStream) {
+		s.irreversible = true
+	}
+}
+
+//WithPollInterval sets how long to wait for a new block once the stream has caught up.
+func WithPollInterval(interval time.Duration) Option {
+	return func(s *BlockStream) {
+		s.pollInterval = interval
+	}
+}
+
+//WithRetryDelay sets how long Blocks waits before trying again after a failed call.
+func WithRetryDelay(delay time.Duration) Option {
+	return func(s *BlockStream) {
+		s.retryDelay = delay
+	}
+}
+
+//WithBatchSize sets how many blocks are fetched at once while catching up.
+func WithBatchSize(n uint32) Option {
+	return func(s *BlockStream) {
+		if n > 0 {
+			s.batchSize = n
+		}
+	}
+}
+
+//WithErrorHandler sets a function called with the errors Blocks retries on.
+func WithErrorHandler(handler func(err error)) Option {
+	return func(s *BlockStream) {
+		s.onError = handler
+	}
+}
+
+//NewBlockStream creates a stream starting at block from, 0 starts at the current
+//head block, or the last irreversible block with WithIrreversibleOnly.
+func NewBlockStream(a *api.API, from uint32, opts ...Option) *BlockStream {
+	s := &BlockStream{
+		api:          a,
+		next:         from,
+		pollInterval: defaultPollInterval,
+		retryDelay:   defaultRetryDelay,
+		batchSize:    defaultBatchSize,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+//NextBlockNum returns the number of the block Next returns next, it can be
+//stored to resume the stream later.
+func (s *BlockStream) NextBlockNum() uint32 {
+	if len(s.buffer) > 0 {
+		return s.buffer[0].Number
+	}
+	return s.next
+}
+
+//Next returns the next block, waiting for it to be produced (or become irreversible).
+//After an error Next can be called again and resumes with the same block.
+func (s *BlockStream) Next(ctx context.Context) (*api.Block, error) {
+	for len(s.buffer) == 0 {
+		if err := s.fill(ctx); err != nil {
+			return nil, err
+		}
+		if len(s.buffer) == 0 {
+			if err := sleep(ctx, s.pollInterval); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	block := s.buffer[0]
+	s.buffer = s.buffer[1:]
+	return block, nil
+}
+
+// fill fetches the blocks that are available up to the head or irreversible block
+func (s *BlockStream) fill(ctx context.Context) error {
+	props, err := s.api.GetDynamicGlobalPropertiesContext(ctx)
+	if err != nil {
+		return err
+	}
+	last := props.HeadBlockNumber
+	if s.irreversible {
+		last = props.LastIrreversibleBlockNum
+	}
+	if s.next == 0 {
+		s.next = last
+	}
+	if s.next == 0 || s.next > last {
+		return nil
+	}
+
+	to := last
+	if to-s.next >= s.batchSize {
+		to = s.next + s.batchSize - 1
+	}
+	blocks, err := s.api.GetBlocksContext(ctx, s.next, to)
+	if err != nil {
+		return err
+	}
+
+	for _, block := range blocks {
+		// a node behind the one that answered the properties does not have the block yet
+		if block.BlockId == "" {
+			break
+		}
+		s.buffer = append(s.buffer, block)
+		s.next = block.Number + 1
+	}
+	return nil
+}
+
+//Blocks runs the stream in a goroutine and sends the blocks on the returned channel,
+//failed calls are retried after the retry delay. The channel is closed when ctx is done,
+//Next must not be called until then.
+func (s *BlockStream) Blocks(ctx context.Context) <-chan *api.Block {
+	ch := make(chan *api.Block)
+	go func() {
+		defer close(ch)
+		for {
+			block, err := s.Next(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				if s.onError != nil {
+					s.onError(err)
+				}
+				if sleep(ctx, s.retryDelay) != nil {
+					return
+				}
+				continue
+			}
+
+			select {
+			case ch <- block:
+			case <-ctx.Done():
+				// keep the block for a later Next
+				s.buffer = append([]*api.Block{block}, s.buffer...)
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
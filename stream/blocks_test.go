@@ -0,0 +1,106 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/thanhxeon2470/beowulf-go/api"
+	"github.com/thanhxeon2470/beowulf-go/types"
+)
+
+// fakeChain answers the condenser_api calls the streams make
+type fakeChain struct {
+	mutex sync.Mutex
+	head  uint32
+	lib   uint32
+	fail  int // number of get_block calls still to fail
+
+	transactions map[uint32][]*types.Transaction
+}
+
+func (c *fakeChain) Call(method string, args []interface{}, reply interface{}, scid string) error {
+	return c.CallContext(context.Background(), method, args, reply, scid)
+}
+
+func (c *fakeChain) CallContext(ctx context.Context, method string, args []interface{}, reply interface{}, scid string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var result interface{}
+	switch args[1] {
+	case "get_dynamic_global_properties":
+		result = map[string]interface{}{"head_block_number": c.head, "last_irreversible_block_num": c.lib}
+	case "get_block":
+		if c.fail > 0 {
+			c.fail--
+			return errors.New("connection reset")
+		}
+		num := args[2].([]uint32)[0]
+		if num > c.head {
+			result = nil
+			break
+		}
+		block := map[string]interface{}{
+			"block_id": fmt.Sprintf("%08x", num),
+			"previous": fmt.Sprintf("%08x", num-1),
+		}
+		if txs := c.transactions[num]; len(txs) > 0 {
+			ids := make([]string, len(txs))
+			for i := range txs {
+				ids[i] = fmt.Sprintf("trx-%d-%d", num, i)
+			}
+			block["transactions"] = txs
+			block["transaction_ids"] = ids
+		}
+		result = block
+	default:
+		return errors.Errorf("unexpected method %v", args[1])
+	}
+	raw, _ := json.Marshal(result)
+	return json.Unmarshal(raw, reply)
+}
+
+func (c *fakeChain) SetCallback(api string, method string, callback func(raw json.RawMessage)) error {
+	return errors.New("not supported")
+}
+
+func (c *fakeChain) set(head, lib uint32) {
+	c.mutex.Lock()
+	c.head, c.lib = head, lib
+	c.mutex.Unlock()
+}
+
+func TestBlockStreamIrreversible(t *testing.T) {
+	chain := &fakeChain{head: 12, lib: 9, fail: 1}
+	s := NewBlockStream(api.NewAPI(chain), 5, WithIrreversibleOnly(), WithBatchSize(2), WithPollInterval(time.Millisecond))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	expect := uint32(5)
+	for expect <= 11 {
+		block, err := s.Next(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				t.Fatal(err)
+			}
+			// a failed call is resumed by the next Next
+			continue
+		}
+		if block.Number != expect || block.BlockId != fmt.Sprintf("%08x", expect) {
+			t.Fatalf("expected block %d, got %d (%s)", expect, block.Number, block.BlockId)
+		}
+		if block.Number > 9 && block.Number > chain.lib {
+			t.Fatalf("block %d emitted above the irreversible block", block.Number)
+		}
+		if expect == 9 {
+			chain.set(14, 11)
+		}
+		expect++
+	}
+}
@@ -0,0 +1,206 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/thanhxeon2470/beowulf-go/api"
+	"github.com/thanhxeon2470/beowulf-go/types"
+)
+
+//Filter selects the operations emitted by an OperationStream
+type Filter func(op *types.OperationObject) bool
+
+//ByOpType matches the operations of the given types.
+func ByOpType(kinds ...types.OpType) Filter {
+	return func(op *types.OperationObject) bool {
+		for _, kind := range kinds {
+			if op.OperationType == kind {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+//ByAccount matches the operations involving one of the given accounts, see InvolvedAccounts.
+func ByAccount(accounts ...string) Filter {
+	return func(op *types.OperationObject) bool {
+		for _, involved := range InvolvedAccounts(op.Operation) {
+			for _, account := range accounts {
+				if involved == account {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+//ByContract matches the smart contract operations calling contractName,
+//an empty contractAction matches every action of the contract.
+func ByContract(contractName, contractAction string) Filter {
+	return func(op *types.OperationObject) bool {
+		sc, ok := op.Operation.(*types.SmartContractOperation)
+		if !ok {
+			return false
+		}
+		name, action, err := ParseScOperation(sc)
+		if err != nil || name != contractName {
+			return false
+		}
+		return contractAction == "" || action == contractAction
+	}
+}
+
+//Any matches the operations matched by at least one of the filters.
+func Any(filters ...Filter) Filter {
+	return func(op *types.OperationObject) bool {
+		for _, filter := range filters {
+			if filter(op) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+//ParseScOperation returns the contractName and contractAction of a smart contract operation.
+func ParseScOperation(op *types.SmartContractOperation) (string, string, error) {
+	var payload struct {
+		ContractName   string `json:"contractName"`
+		ContractAction string `json:"contractAction"`
+	}
+	if err := json.Unmarshal([]byte(op.ScOperation), &payload); err != nil {
+		return "", "", err
+	}
+	return payload.ContractName, payload.ContractAction, nil
+}
+
+//InvolvedAccounts returns the accounts named by the from/to/creator/owner/account
+//fields of the operation.
+func InvolvedAccounts(op types.Operation) []string {
+	switch op := op.(type) {
+	case *types.TransferOperation:
+		return []string{op.From, op.To}
+	case *types.TransferToVestingOperation:
+		return []string{op.From, op.To}
+	case *types.WithdrawVestingOperation:
+		return []string{op.Account}
+	case *types.AccountCreateOperation:
+		return []string{op.Creator, op.NewAccountName}
+	case *types.AccountUpdateOperation:
+		return []string{op.Account}
+	case *types.SupernodeUpdateOperation:
+		return []string{op.Owner}
+	case *types.AccountSupernodeVoteOperation:
+		return []string{op.Account, op.Supernode}
+	case *types.SmtCreateOperation:
+		return []string{op.Creator, op.ControlAccount}
+	case *types.SmartContractOperation:
+		return op.RequiredOwners
+	case *types.CheckSidechainOperation:
+		return []string{op.Committer}
+	}
+	return nil
+}
+
+//OperationStream flattens the blocks of a BlockStream into their operations.
+type OperationStream struct {
+	blocks  *BlockStream
+	filters []Filter
+
+	buffer []*types.OperationObject
+}
+
+//NewOperationStream creates a stream of the operations in the blocks that match all filters.
+func NewOperationStream(blocks *BlockStream, filters ...Filter) *OperationStream {
+	return &OperationStream{
+		blocks:  blocks,
+		filters: filters,
+	}
+}
+
+//Next returns the next matching operation, the operations of a block are only
+//dropped once all of them were returned.
+func (s *OperationStream) Next(ctx context.Context) (*types.OperationObject, error) {
+	for len(s.buffer) == 0 {
+		block, err := s.blocks.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		s.buffer = s.flatten(block)
+	}
+
+	op := s.buffer[0]
+	s.buffer = s.buffer[1:]
+	return op, nil
+}
+
+func (s *OperationStream) flatten(block *api.Block) []*types.OperationObject {
+	var ops []*types.OperationObject
+	for i, tx := range block.Transactions {
+		var trxID string
+		if i < len(block.TransactionIds) {
+			trxID = block.TransactionIds[i]
+		}
+		for j, op := range tx.Operations {
+			obj := &types.OperationObject{
+				TransactionID:          trxID,
+				BlockNumber:            block.Number,
+				TransactionInBlock:     uint32(i),
+				OperationInTransaction: uint32(j),
+				Timestamp:              block.Timestamp,
+				Operation:              op,
+				OperationType:          op.Type(),
+			}
+			if s.match(obj) {
+				ops = append(ops, obj)
+			}
+		}
+	}
+	return ops
+}
+
+func (s *OperationStream) match(op *types.OperationObject) bool {
+	for _, filter := range s.filters {
+		if !filter(op) {
+			return false
+		}
+	}
+	return true
+}
+
+//Operations runs the stream in a goroutine and sends the operations on the returned
+//channel, failed calls are retried like in BlockStream.Blocks. The channel is closed
+//when ctx is done, Next must not be called until then.
+func (s *OperationStream) Operations(ctx context.Context) <-chan *types.OperationObject {
+	ch := make(chan *types.OperationObject)
+	go func() {
+		defer close(ch)
+		for {
+			op, err := s.Next(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				if s.blocks.onError != nil {
+					s.blocks.onError(err)
+				}
+				if sleep(ctx, s.blocks.retryDelay) != nil {
+					return
+				}
+				continue
+			}
+
+			select {
+			case ch <- op:
+			case <-ctx.Done():
+				// keep the operation for a later Next
+				s.buffer = append([]*types.OperationObject{op}, s.buffer...)
+				return
+			}
+		}
+	}()
+	return ch
+}
@@ -0,0 +1,60 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/thanhxeon2470/beowulf-go/api"
+	"github.com/thanhxeon2470/beowulf-go/types"
+)
+
+func TestOperationStreamFilters(t *testing.T) {
+	transfer := &types.TransferOperation{From: "alice", To: "bob", Amount: types.MustParseAsset("1.00000 BWF"), Fee: types.MustParseAsset("0.01000 W")}
+	vote := &types.AccountSupernodeVoteOperation{Account: "carol", Supernode: "dave", Fee: types.MustParseAsset("0.01000 W")}
+	mint := &types.SmartContractOperation{
+		RequiredOwners: types.StringSlice{"erin"},
+		Scid:           "s01",
+		ScOperation:    `{"contractName":"nft","contractAction":"issue","contractPayload":{}}`,
+		Fee:            types.MustParseAsset("0.01000 W"),
+	}
+	burn := &types.SmartContractOperation{
+		RequiredOwners: types.StringSlice{"bob"},
+		Scid:           "s01",
+		ScOperation:    `{"contractName":"nft","contractAction":"burn","contractPayload":{}}`,
+		Fee:            types.MustParseAsset("0.01000 W"),
+	}
+	chain := &fakeChain{head: 3, lib: 3, transactions: map[uint32][]*types.Transaction{
+		2: {{Operations: types.Operations{transfer, vote}}},
+		3: {{Operations: types.Operations{vote}}, {Operations: types.Operations{mint, burn}}},
+	}}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		expect []string // trx id and op index of the expected operations
+	}{
+		{"type", ByOpType(types.TypeAccountSupernodeVote), []string{"trx-2-0/1", "trx-3-0/0"}},
+		{"account", ByAccount("bob"), []string{"trx-2-0/0", "trx-3-1/1"}},
+		{"contract", ByContract("nft", "issue"), []string{"trx-3-1/0"}},
+		{"any", Any(ByContract("nft", ""), ByAccount("alice")), []string{"trx-2-0/0", "trx-3-1/0", "trx-3-1/1"}},
+	}
+	for _, test := range tests {
+		blocks := NewBlockStream(api.NewAPI(chain), 1, WithPollInterval(time.Millisecond))
+		s := NewOperationStream(blocks, test.filter)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+
+		for _, expect := range test.expect {
+			op, err := s.Next(ctx)
+			if err != nil {
+				t.Fatalf("%s: %v", test.name, err)
+			}
+			got := fmt.Sprintf("%s/%d", op.TransactionID, op.OperationInTransaction)
+			if got != expect {
+				t.Errorf("%s: expected %s, got %s", test.name, expect, got)
+			}
+		}
+		cancel()
+	}
+}
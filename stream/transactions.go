@@ -0,0 +1,348 @@
+package stream
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/thanhxeon2470/beowulf-go/api"
+)
+
+const (
+	defaultCheckInterval = 3 * time.Second
+	// the expiration assumed for a transaction the node does not know, BEOWULF_MAX_TIME_UNTIL_EXPIRATION
+	defaultExpiration = time.Hour
+)
+
+//Level is how far a watched transaction has to go before it is confirmed
+type Level int
+
+const (
+	//Included confirms a transaction once it is in a head block, which a fork can still revert.
+	Included Level = iota
+	//Irreversible confirms a transaction once its block is at or below LastIrreversibleBlockNum.
+	Irreversible
+)
+
+//TransactionState is the final state of a watched transaction
+type TransactionState int
+
+const (
+	StateIncluded TransactionState = iota
+	StateIrreversible
+	StateExpired
+)
+
+func (s TransactionState) String() string {
+	switch s {
+	case StateIncluded:
+		return "included"
+	case StateIrreversible:
+		return "irreversible"
+	case StateExpired:
+		return "expired"
+	}
+	return "unknown"
+}
+
+//Confirmation is the outcome of a watched transaction, BlockNum and TrxNum locate it
+//in the chain unless it expired.
+type Confirmation struct {
+	ID       string
+	State    TransactionState
+	BlockNum uint32
+	TrxNum   uint32
+}
+
+//TrackerOption configures the Tracker created by NewTracker
+type TrackerOption func(*Tracker)
+
+//WithIrreversibleCheckInterval sets how often LastIrreversibleBlockNum is read while
+//included transactions wait to become irreversible.
+func WithIrreversibleCheckInterval(interval time.Duration) TrackerOption {
+	return func(t *Tracker) {
+		t.checkInterval = interval
+	}
+}
+
+//WithStreamOptions configures the block stream of the tracker, e.g. WithPollInterval.
+//The handler set with WithErrorHandler also receives the errors of the tracker calls.
+func WithStreamOptions(opts ...Option) TrackerOption {
+	return func(t *Tracker) {
+		t.streamOpts = append(t.streamOpts, opts...)
+	}
+}
+
+//Tracker watches many transactions at once: their status is asked once when they are
+//watched, then the ids of the streamed head blocks are matched against them and a single
+//GetDynamicGlobalProperties call per check interval tells which became irreversible.
+//A transaction not included in a block at or after its expiration is expired.
+type Tracker struct {
+	api           *api.API
+	checkInterval time.Duration
+	streamOpts    []Option
+	onError       func(err error)
+
+	mutex   sync.Mutex
+	watches map[string][]*watch
+	done    bool
+	added   chan struct{}
+}
+
+type watch struct {
+	level      Level
+	expiration time.Time // zero until the node answered its status
+	checked    bool
+	blockNum   uint32 // the block it is included in, 0 while it is not
+	trxNum     uint32
+	ch         chan Confirmation
+}
+
+//NewTracker creates a tracker, it follows the chain once Run is called.
+func NewTracker(a *api.API, opts ...TrackerOption) *Tracker {
+	t := &Tracker{
+		api:           a,
+		checkInterval: defaultCheckInterval,
+		watches:       make(map[string][]*watch),
+		added:         make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+//Watch returns a channel receiving the confirmation of the transaction id at the given
+//level, or its expiration. A zero expiration is taken from the node, and is an hour from
+//now when the node does not know the transaction either. The channel is closed after
+//the confirmation, or without one when Run returns first.
+func (t *Tracker) Watch(id string, expiration time.Time, level Level) <-chan Confirmation {
+	ch := make(chan Confirmation, 1)
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.done {
+		close(ch)
+		return ch
+	}
+	t.watches[id] = append(t.watches[id], &watch{level: level, expiration: expiration, ch: ch})
+	select {
+	case t.added <- struct{}{}:
+	default:
+	}
+	return ch
+}
+
+//Run follows the chain until ctx is done and resolves the watched transactions, it can
+//only be called once.
+func (t *Tracker) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer t.closeAll()
+
+	s := NewBlockStream(t.api, 0, t.streamOpts...)
+	t.onError = s.onError
+	// the blocks up to the current head are covered by the status asked for each watch
+	for {
+		props, err := t.api.GetDynamicGlobalPropertiesContext(ctx)
+		if err == nil {
+			s.next = props.HeadBlockNumber
+			break
+		}
+		t.fail(ctx, err)
+		if err := sleep(ctx, s.retryDelay); err != nil {
+			return err
+		}
+	}
+
+	blocks := s.Blocks(ctx)
+	ticker := time.NewTicker(t.checkInterval)
+	defer ticker.Stop()
+	for {
+		t.checkNew(ctx)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case block, ok := <-blocks:
+			if !ok {
+				return ctx.Err()
+			}
+			t.onBlock(block)
+		case <-ticker.C:
+			t.checkIrreversible(ctx)
+		case <-t.added:
+		}
+	}
+}
+
+func (t *Tracker) fail(ctx context.Context, err error) {
+	if ctx.Err() == nil && t.onError != nil {
+		t.onError(err)
+	}
+}
+
+// resolve sends the confirmation and drops the watch, the mutex must be held
+func (t *Tracker) resolve(id string, w *watch, state TransactionState) {
+	w.ch <- Confirmation{ID: id, State: state, BlockNum: w.blockNum, TrxNum: w.trxNum}
+	close(w.ch)
+	watches := t.watches[id]
+	for i := range watches {
+		if watches[i] == w {
+			t.watches[id] = append(watches[:i], watches[i+1:]...)
+			break
+		}
+	}
+	if len(t.watches[id]) == 0 {
+		delete(t.watches, id)
+	}
+}
+
+// resolveAll resolves the watches of id that the state satisfies, the mutex must be held
+func (t *Tracker) resolveAll(id string, state TransactionState) {
+	for _, w := range append([]*watch(nil), t.watches[id]...) {
+		if state != StateIncluded || w.level == Included {
+			t.resolve(id, w, state)
+		}
+	}
+}
+
+// checkNew asks the status of the transactions watched since the last check
+func (t *Tracker) checkNew(ctx context.Context) {
+	t.mutex.Lock()
+	var ids []string
+	for id, watches := range t.watches {
+		for _, w := range watches {
+			if !w.checked {
+				ids = append(ids, id)
+				break
+			}
+		}
+	}
+	t.mutex.Unlock()
+
+	for _, id := range ids {
+		resp, err := t.api.GetTransactionWithStatusContext(ctx, id)
+		if err != nil {
+			t.fail(ctx, err)
+			continue
+		}
+
+		t.mutex.Lock()
+		var blockNum, trxNum uint32
+		if resp.BlockNum != nil {
+			blockNum = uint32(*resp.BlockNum)
+		}
+		if resp.TransactionNum != nil {
+			trxNum = uint32(*resp.TransactionNum)
+		}
+		expiration := time.Now().Add(defaultExpiration)
+		if resp.Expiration != nil && resp.Expiration.Time != nil {
+			expiration = *resp.Expiration.Time
+		}
+		for _, w := range t.watches[id] {
+			if w.checked {
+				continue
+			}
+			w.checked = true
+			if w.expiration.IsZero() {
+				w.expiration = expiration
+			}
+			if w.blockNum == 0 {
+				w.blockNum, w.trxNum = blockNum, trxNum
+			}
+		}
+		switch resp.Status {
+		case api.TransactionStatusExpiredReversible, api.TransactionStatusExpiredIrreversible:
+			t.resolveAll(id, StateExpired)
+		case api.TransactionStatusWithinIrreversibleBlock:
+			t.resolveAll(id, StateIrreversible)
+		case api.TransactionStatusWithinReversibleBlock:
+			t.resolveAll(id, StateIncluded)
+		}
+		t.mutex.Unlock()
+	}
+}
+
+// onBlock finds the watched transactions in the block and expires those it proves expired
+func (t *Tracker) onBlock(block *api.Block) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	for i, id := range block.TransactionIds {
+		for _, w := range t.watches[id] {
+			w.blockNum, w.trxNum = block.Number, uint32(i)
+		}
+		t.resolveAll(id, StateIncluded)
+	}
+
+	if block.Timestamp == nil || block.Timestamp.Time == nil {
+		return
+	}
+	// a block at or after the expiration can no longer include the transaction, a watch added
+	// since the last check may be in an earlier block the node has not been asked about yet
+	for id, watches := range t.watches {
+		for _, w := range append([]*watch(nil), watches...) {
+			if w.checked && w.blockNum == 0 && !w.expiration.IsZero() && !w.expiration.After(*block.Timestamp.Time) {
+				t.resolve(id, w, StateExpired)
+			}
+		}
+	}
+}
+
+// checkIrreversible resolves the included transactions at or below the last irreversible block
+func (t *Tracker) checkIrreversible(ctx context.Context) {
+	t.mutex.Lock()
+	waiting := false
+	for _, watches := range t.watches {
+		for _, w := range watches {
+			waiting = waiting || w.blockNum > 0
+		}
+	}
+	t.mutex.Unlock()
+	if !waiting {
+		return
+	}
+
+	props, err := t.api.GetDynamicGlobalPropertiesContext(ctx)
+	if err != nil {
+		t.fail(ctx, err)
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	for id, watches := range t.watches {
+		for _, w := range append([]*watch(nil), watches...) {
+			if w.blockNum > 0 && w.blockNum <= props.LastIrreversibleBlockNum {
+				t.resolve(id, w, StateIrreversible)
+			}
+		}
+	}
+}
+
+// closeAll closes the channels of the unresolved watches once Run returns
+func (t *Tracker) closeAll() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	for id, watches := range t.watches {
+		for _, w := range watches {
+			close(w.ch)
+		}
+		delete(t.watches, id)
+	}
+	t.done = true
+}
+
+//WaitForTransaction waits until the transaction id reaches the level or expires, see Tracker.
+func WaitForTransaction(ctx context.Context, a *api.API, id string, level Level, opts ...TrackerOption) (*Confirmation, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	tracker := NewTracker(a, opts...)
+	ch := tracker.Watch(id, time.Time{}, level)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- tracker.Run(ctx)
+	}()
+
+	if confirmation, ok := <-ch; ok {
+		return &confirmation, nil
+	}
+	return nil, <-errc
+}
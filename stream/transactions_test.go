@@ -0,0 +1,60 @@
+package stream_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/thanhxeon2470/beowulf-go/client"
+	"github.com/thanhxeon2470/beowulf-go/config"
+	"github.com/thanhxeon2470/beowulf-go/mocknode"
+	"github.com/thanhxeon2470/beowulf-go/stream"
+)
+
+func TestTracker(t *testing.T) {
+	node := mocknode.New(mocknode.WithBlockInterval(50 * time.Millisecond))
+	defer node.Close()
+	wif := client.CreatePrivateKey("alice", "owner", "password")
+	if err := node.CreateAccount("alice", client.CreatePublicKey(config.ADDRESS_PREFIX, wif), "1.00000 W"); err != nil {
+		t.Fatal(err)
+	}
+	if err := node.CreateAccount("bob", client.CreatePublicKey(config.ADDRESS_PREFIX, wif)); err != nil {
+		t.Fatal(err)
+	}
+	cls, err := client.NewClient(node.URL(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cls.Close()
+	cls.SetKeys(&client.Keys{OKey: []string{wif}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	tracker := stream.NewTracker(cls.API, stream.WithIrreversibleCheckInterval(10*time.Millisecond),
+		stream.WithStreamOptions(stream.WithPollInterval(10*time.Millisecond)))
+	go tracker.Run(ctx)
+
+	resp, err := cls.Transfer("alice", "bob", "", "0.10000 W", "0.01000 W")
+	if err != nil {
+		t.Fatal(err)
+	}
+	included := tracker.Watch(resp.Bresp.ID, time.Time{}, stream.Included)
+	irreversible := tracker.Watch(resp.Bresp.ID, time.Time{}, stream.Irreversible)
+	unknown := tracker.Watch("0000000000000000000000000000000000000000", time.Now().Add(time.Second), stream.Included)
+
+	first := <-included
+	if first.State != stream.StateIncluded || first.BlockNum == 0 {
+		t.Errorf("included %+v", first)
+	}
+	if c := <-irreversible; c.State != stream.StateIrreversible || c.BlockNum != first.BlockNum {
+		t.Errorf("irreversible %+v", c)
+	}
+	if c := <-unknown; c.State != stream.StateExpired {
+		t.Errorf("unknown %+v", c)
+	}
+
+	c, err := cls.WaitForTransaction(ctx, resp.Bresp.ID, stream.Irreversible)
+	if err != nil || c.State != stream.StateIrreversible || c.TrxNum != first.TrxNum {
+		t.Errorf("wait %+v, %v", c, err)
+	}
+}
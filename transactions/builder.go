@@ -0,0 +1,175 @@
+//go:build !nosigning
+// +build !nosigning
+
+package transactions
+
+import (
+	// Stdlib
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	// RPC
+	"github.com/thanhxeon2470/beowulf-go/types"
+
+	// Vendor
+	"github.com/pkg/errors"
+)
+
+//Builder assembles a transaction from explicitly given parameters, it never talks
+//to a node so it can be used on an offline signing machine.
+type Builder struct {
+	chainID        string
+	refBlockNum    types.UInt16
+	refBlockPrefix types.UInt32
+	refBlockSet    bool
+	expiration     time.Time
+	createdTime    time.Time
+	operations     types.Operations
+	extensions     []interface{}
+	err            error
+}
+
+//NewBuilder starts a transaction for the given chain id (config.CHAIN_ID_MAINNET or config.CHAIN_ID_TESTNET).
+func NewBuilder(chainID string) *Builder {
+	return &Builder{chainID: chainID}
+}
+
+//RefBlock sets the reference block from its number and hex id, e.g. a block a few
+//blocks below head taken from an online machine.
+func (b *Builder) RefBlock(blockNum uint32, blockID string) *Builder {
+	prefix, err := RefBlockPrefix(blockID)
+	if err != nil {
+		b.setErr(err)
+		return b
+	}
+	b.refBlockNum = RefBlockNum(blockNum)
+	b.refBlockPrefix = prefix
+	b.refBlockSet = true
+	return b
+}
+
+//Expiration sets the time after which the transaction is rejected by the chain.
+func (b *Builder) Expiration(expiration time.Time) *Builder {
+	b.expiration = expiration.UTC().Truncate(time.Second)
+	return b
+}
+
+//CreatedTime sets the creation time of the transaction, the build time is used if not set.
+func (b *Builder) CreatedTime(created time.Time) *Builder {
+	b.createdTime = created.UTC()
+	return b
+}
+
+//Operations adds operations to the transaction.
+func (b *Builder) Operations(ops ...types.Operation) *Builder {
+	b.operations = append(b.operations, ops...)
+	return b
+}
+
+//Extension adds a JSON extension with the given data to the transaction.
+func (b *Builder) Extension(data string) *Builder {
+	b.extensions = append(b.extensions, &types.ExtensionType{
+		Type:  uint8(types.ExtJsonType.Code()),
+		Value: types.ExtensionJsonType{Data: data},
+	})
+	return b
+}
+
+func (b *Builder) setErr(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+//Build returns the unsigned transaction.
+func (b *Builder) Build() (*SignedTransaction, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if !b.refBlockSet {
+		return nil, errors.New("builder: reference block is not set")
+	}
+	if b.expiration.IsZero() {
+		return nil, errors.New("builder: expiration is not set")
+	}
+	if len(b.operations) == 0 {
+		return nil, errors.New("builder: no operation specified")
+	}
+	if _, err := hex.DecodeString(b.chainID); err != nil || len(b.chainID) == 0 {
+		return nil, errors.Errorf("builder: invalid chain ID: %q", b.chainID)
+	}
+
+	expiration := b.expiration
+	created := b.createdTime
+	if created.IsZero() {
+		created = time.Now().UTC()
+	}
+	extensions := b.extensions
+	if extensions == nil {
+		extensions = []interface{}{}
+	}
+
+	return NewSignedTransaction(&types.Transaction{
+		RefBlockNum:    b.refBlockNum,
+		RefBlockPrefix: b.refBlockPrefix,
+		Expiration:     &types.Time{Time: &expiration},
+		Operations:     append(types.Operations{}, b.operations...),
+		Extensions:     extensions,
+		CreatedTime:    types.UInt64(created.Unix()),
+		Signatures:     []string{},
+	}), nil
+}
+
+//Envelope builds the transaction and wraps it for the transfer to a signing machine.
+func (b *Builder) Envelope() (*Envelope, error) {
+	tx, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	return NewEnvelope(tx, b.chainID)
+}
+
+//Envelope is a portable form of a transaction moved between an online machine that
+//broadcasts it and an offline machine that signs it. Hex holds the serialized transaction
+//including its signatures and is what is read back, Transaction is informational.
+type Envelope struct {
+	ChainID     string             `json:"chain_id"`
+	Hex         string             `json:"hex"`
+	Transaction *types.Transaction `json:"transaction"`
+}
+
+//NewEnvelope wraps the transaction and its current signatures.
+func NewEnvelope(tx *SignedTransaction, chainID string) (*Envelope, error) {
+	raw, err := tx.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	return &Envelope{
+		ChainID:     chainID,
+		Hex:         hex.EncodeToString(raw),
+		Transaction: tx.Transaction,
+	}, nil
+}
+
+//ParseEnvelope reads an envelope from its JSON form.
+func ParseEnvelope(data []byte) (*Envelope, error) {
+	var env Envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal transaction envelope")
+	}
+	if len(env.Hex) == 0 {
+		return nil, errors.New("transaction envelope has no hex")
+	}
+	return &env, nil
+}
+
+//JSON returns the JSON form of the envelope.
+func (env *Envelope) JSON() ([]byte, error) {
+	return json.Marshal(env)
+}
+
+//SignedTransaction restores the transaction from the hex form, ready to be signed or broadcast.
+func (env *Envelope) SignedTransaction() (*SignedTransaction, error) {
+	return DeserializeHex(env.Hex)
+}
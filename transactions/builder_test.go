@@ -0,0 +1,94 @@
+package transactions
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/thanhxeon2470/beowulf-go/config"
+	"github.com/thanhxeon2470/beowulf-go/types"
+)
+
+func TestBuilderEnvelopeRoundTrip(t *testing.T) {
+	expiration := time.Unix(1600003600, 0)
+	env, err := NewBuilder(config.CHAIN_ID_TESTNET).
+		RefBlock(1234, "000004d2a1b2c3d4e5f60718293a4b5c6d7e8f90").
+		Expiration(expiration).
+		CreatedTime(time.Unix(1600000000, 0)).
+		Operations(&types.TransferOperation{
+			From:   "alice",
+			To:     "bob",
+			Amount: types.MustParseAsset("1.00000 BWF"),
+			Fee:    types.MustParseAsset("0.01000 W"),
+		}).
+		Extension("memo").
+		Envelope()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// offline machine: parse, sign and wrap again
+	data, err := env.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	offline, err := ParseEnvelope(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := offline.SignedTransaction()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.RefBlockNum != 1234 || tx.RefBlockPrefix != 0xd4c3b2a1 || !tx.Expiration.Equal(expiration) {
+		t.Fatalf("unexpected header %+v", tx.Transaction)
+	}
+
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.Sign([][]byte{priv.Serialize()}, offline.ChainID); err != nil {
+		t.Fatal(err)
+	}
+	signed, err := NewEnvelope(tx, offline.ChainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// online machine: the signatures and every other byte survive the trip
+	data, err = signed.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	online, err := ParseEnvelope(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	final, err := online.SignedTransaction()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(final.Signatures) != 1 || final.Signatures[0] != tx.Signatures[0] {
+		t.Errorf("signatures lost: %v", final.Signatures)
+	}
+	a, _ := tx.Serialize()
+	b, _ := final.Serialize()
+	if !bytes.Equal(a, b) {
+		t.Error("transaction bytes differ after the round trip")
+	}
+}
+
+func TestBuilderRequiresParameters(t *testing.T) {
+	op := &types.TransferOperation{From: "alice", To: "bob", Amount: types.MustParseAsset("1.00000 BWF"), Fee: types.MustParseAsset("0.01000 W")}
+	if _, err := NewBuilder(config.CHAIN_ID_TESTNET).Expiration(time.Now()).Operations(op).Build(); err == nil {
+		t.Error("expected an error without reference block")
+	}
+	if _, err := NewBuilder(config.CHAIN_ID_TESTNET).RefBlock(1, "0000000100000000").Operations(op).Build(); err == nil {
+		t.Error("expected an error without expiration")
+	}
+	if _, err := NewBuilder("").RefBlock(1, "0000000100000000").Expiration(time.Now()).Operations(op).Build(); err == nil {
+		t.Error("expected an error without chain ID")
+	}
+}
@@ -0,0 +1,236 @@
+//go:build !nosigning
+// +build !nosigning
+
+package transactions
+
+import (
+	// Stdlib
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net"
+	"os/exec"
+	"sync"
+
+	// Vendor
+	"github.com/pkg/errors"
+)
+
+// The external signer protocol exchanges one JSON object per line:
+//
+//	-> {"id":1,"method":"public_keys"}
+//	<- {"id":1,"result":["BEO..."]}
+//	-> {"id":2,"method":"sign_digest","public_key":"BEO...","digest":"<hex>"}
+//	<- {"id":2,"result":"<hex of the 65-byte signature>"}
+//
+// A failed request is answered with {"id":n,"error":"message"}.
+const (
+	signerMethodPublicKeys = "public_keys"
+	signerMethodSignDigest = "sign_digest"
+)
+
+type signerRequest struct {
+	ID        uint64 `json:"id"`
+	Method    string `json:"method"`
+	PublicKey string `json:"public_key,omitempty"`
+	Digest    string `json:"digest,omitempty"`
+}
+
+type signerResponse struct {
+	ID     uint64          `json:"id"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+//SignerConn is a connection to an external signer process holding the private keys
+type SignerConn struct {
+	mutex  sync.Mutex
+	enc    *json.Encoder
+	dec    *json.Decoder
+	closer io.Closer
+	lastID uint64
+}
+
+//NewSignerConn speaks the signer protocol over rw, e.g. an already open socket.
+func NewSignerConn(rw io.ReadWriteCloser) *SignerConn {
+	return &SignerConn{
+		enc:    json.NewEncoder(rw),
+		dec:    json.NewDecoder(bufio.NewReader(rw)),
+		closer: rw,
+	}
+}
+
+//DialSigner connects to a signer listening on a local socket, e.g. DialSigner("unix", "/run/beowulf-signer.sock").
+func DialSigner(network, address string) (*SignerConn, error) {
+	conn, err := net.Dial(network, address)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to the signer")
+	}
+	return NewSignerConn(conn), nil
+}
+
+//StartSignerProcess starts the signer program and speaks the protocol over its stdin and stdout.
+func StartSignerProcess(name string, args ...string) (*SignerConn, error) {
+	cmd := exec.Command(name, args...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, errors.Wrap(err, "failed to start the signer")
+	}
+	return NewSignerConn(&processPipe{cmd: cmd, stdin: stdin, stdout: stdout}), nil
+}
+
+type processPipe struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout io.ReadCloser
+}
+
+func (p *processPipe) Read(b []byte) (int, error)  { return p.stdout.Read(b) }
+func (p *processPipe) Write(b []byte) (int, error) { return p.stdin.Write(b) }
+
+// Close ends the input of the signer and waits for it to exit
+func (p *processPipe) Close() error {
+	p.stdin.Close()
+	return p.cmd.Wait()
+}
+
+func (conn *SignerConn) call(method, publicKey string, digest []byte, result interface{}) error {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+
+	conn.lastID++
+	req := signerRequest{ID: conn.lastID, Method: method, PublicKey: publicKey}
+	if digest != nil {
+		req.Digest = hex.EncodeToString(digest)
+	}
+	if err := conn.enc.Encode(req); err != nil {
+		return errors.Wrap(err, "failed to send the signer request")
+	}
+
+	var resp signerResponse
+	if err := conn.dec.Decode(&resp); err != nil {
+		return errors.Wrap(err, "failed to read the signer response")
+	}
+	if resp.ID != req.ID {
+		return errors.Errorf("signer answered request %d instead of %d", resp.ID, req.ID)
+	}
+	if resp.Error != "" {
+		return errors.Errorf("signer: %s", resp.Error)
+	}
+	return json.Unmarshal(resp.Result, result)
+}
+
+//PublicKeys returns the keys the signer can sign with.
+func (conn *SignerConn) PublicKeys() ([]string, error) {
+	var keys []string
+	err := conn.call(signerMethodPublicKeys, "", nil, &keys)
+	return keys, err
+}
+
+//Signer returns a Signer signing with the given key of the external signer.
+func (conn *SignerConn) Signer(publicKey string) Signer {
+	return &remoteSigner{conn: conn, publicKey: publicKey}
+}
+
+//Signers returns a Signer for every key of the external signer.
+func (conn *SignerConn) Signers() ([]Signer, error) {
+	keys, err := conn.PublicKeys()
+	if err != nil {
+		return nil, err
+	}
+	signers := make([]Signer, 0, len(keys))
+	for _, key := range keys {
+		signers = append(signers, conn.Signer(key))
+	}
+	return signers, nil
+}
+
+//Close closes the connection to the signer.
+func (conn *SignerConn) Close() error {
+	return conn.closer.Close()
+}
+
+type remoteSigner struct {
+	conn      *SignerConn
+	publicKey string
+}
+
+func (signer *remoteSigner) PublicKey() string {
+	return signer.publicKey
+}
+
+func (signer *remoteSigner) SignDigest(digest []byte) ([]byte, error) {
+	var sigHex string
+	if err := signer.conn.call(signerMethodSignDigest, signer.publicKey, digest, &sigHex); err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(sigHex)
+}
+
+//ServeSigner is the signer side of the protocol: it answers the requests read from r
+//with the given signers until r is exhausted. A signer program can simply call
+//ServeSigner(os.Stdin, os.Stdout, signers...).
+func ServeSigner(r io.Reader, w io.Writer, signers ...Signer) error {
+	byKey := make(map[string]Signer, len(signers))
+	keys := make([]string, 0, len(signers))
+	for _, signer := range signers {
+		byKey[signer.PublicKey()] = signer
+		keys = append(keys, signer.PublicKey())
+	}
+
+	dec := json.NewDecoder(bufio.NewReader(r))
+	enc := json.NewEncoder(w)
+	for {
+		var req signerRequest
+		if err := dec.Decode(&req); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		var result interface{}
+		var err error
+		switch req.Method {
+		case signerMethodPublicKeys:
+			result = keys
+		case signerMethodSignDigest:
+			result, err = serveSignDigest(byKey[req.PublicKey], req)
+		default:
+			err = errors.Errorf("unknown method %q", req.Method)
+		}
+
+		resp := signerResponse{ID: req.ID}
+		if err != nil {
+			resp.Error = err.Error()
+		} else if resp.Result, err = json.Marshal(result); err != nil {
+			return err
+		}
+		if err := enc.Encode(resp); err != nil {
+			return err
+		}
+	}
+}
+
+func serveSignDigest(signer Signer, req signerRequest) (string, error) {
+	if signer == nil {
+		return "", errors.Errorf("unknown key %s", req.PublicKey)
+	}
+	digest, err := hex.DecodeString(req.Digest)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid digest")
+	}
+	sig, err := signer.SignDigest(digest)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(sig), nil
+}
@@ -18,25 +18,18 @@ import (
 	"hash"
 	"math/big"
 	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/sha256"
 	"encoding/binary"
-	"math/rand"
 )
 
 //var one = big.NewInt(1)
 var oneInitializer = []byte{0x01}
 
-func RandStringBytes(n int) string {
-	letterBytes := "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
-
-	b := make([]byte, n)
-	for i := range b {
-		b[i] = letterBytes[rand.Intn(len(letterBytes))]
-	}
-	return string(b)
-}
-
 // https://tools.ietf.org/html/rfc6979#section-3.2
+//
+// A nonce > 0 is extra entropy: the hash is replaced by sha256(hash || nonce) so that
+// another k is derived for the same key and message, nonce 0 is plain RFC 6979.
 func generateSecret(priv *ecdsa.PrivateKey, alg func() hash.Hash, hash []byte, test func(*big.Int) bool, nonce int) error {
 	var hashClone = make([]byte, len(hash))
 	var err error
@@ -55,8 +48,9 @@ func generateSecret(priv *ecdsa.PrivateKey, alg func() hash.Hash, hash []byte, t
 	}
 
 	c := priv.PublicKey.Curve
-	x := priv.D.Bytes()
 	q := c.Params().N
+	x := int2octets(priv.D, q)
+	hashClone = bits2octets(hashClone, c)
 
 	// Step B
 	v := bytes.Repeat(oneInitializer, 32)
@@ -126,6 +120,25 @@ func generateSecret(priv *ecdsa.PrivateKey, alg func() hash.Hash, hash []byte, t
 	return nil
 }
 
+// int2octets is the fixed length encoding of section 2.3.3, a key with leading zero
+// bytes would otherwise give another k than other implementations
+func int2octets(v, q *big.Int) []byte {
+	out := make([]byte, (q.BitLen()+7)/8)
+	b := v.Bytes()
+	copy(out[len(out)-len(b):], b)
+	return out
+}
+
+// bits2octets of section 2.3.4
+func bits2octets(hash []byte, c elliptic.Curve) []byte {
+	q := c.Params().N
+	z := hashToInt(hash, c)
+	if z.Cmp(q) >= 0 {
+		z.Sub(z, q)
+	}
+	return int2octets(z, q)
+}
+
 func HmacSHA256(m, k []byte) ([]byte, error) {
 	mac := hmac.New(sha256.New, k)
 	_, err := mac.Write(m)
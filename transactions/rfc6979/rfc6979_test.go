@@ -0,0 +1,70 @@
+package rfc6979
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+)
+
+// Widely used secp256k1 vectors of RFC 6979 with HMAC-SHA256 and low S
+var signVectors = []struct {
+	key  string
+	msg  string
+	r, s string
+}{
+	{
+		key: "0000000000000000000000000000000000000000000000000000000000000001",
+		msg: "Satoshi Nakamoto",
+		r:   "934b1ea10a4b3c1757e2b0c017d0b6143ce3c9a7e6a4a49860d7a6ab210ee3d8",
+		s:   "2442ce9d2b916064108014783e923ec36b49743e2ffa1c4496f01a512aafd9e5",
+	},
+	{
+		key: "0000000000000000000000000000000000000000000000000000000000000001",
+		msg: "All those moments will be lost in time, like tears in rain. Time to die...",
+		r:   "8600dbd41e348fe5c9465ab92d23e3db8b98b873beecd930736488696438cb6b",
+		s:   "547fe64427496db33bf66019dacbf0039c04199abb0122918601db38a72cfc21",
+	},
+	{
+		key: "fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364140",
+		msg: "Satoshi Nakamoto",
+		r:   "fd567d121db66e382991534ada77a6bd3106f0a1098c231e47993447cd6af2d0",
+		s:   "6b39cd0eb1bc8603e159ef5c20a5c8ad685a45b06ce9bebed3f153d10d93bed5",
+	},
+}
+
+func TestSignECDSAVectors(t *testing.T) {
+	for _, v := range signVectors {
+		keyBytes, _ := hex.DecodeString(v.key)
+		priv, _ := btcec.PrivKeyFromBytes(btcec.S256(), keyBytes)
+		hash := sha256.Sum256([]byte(v.msg))
+
+		r, s, err := SignECDSA(priv.ToECDSA(), hash[:], sha256.New, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(r.Bytes()); got != v.r {
+			t.Errorf("%q: r = %s, want %s", v.msg, got, v.r)
+		}
+		if got := hex.EncodeToString(s.Bytes()); got != v.s {
+			t.Errorf("%q: s = %s, want %s", v.msg, got, v.s)
+		}
+	}
+}
+
+func TestSignECDSANonce(t *testing.T) {
+	priv, _ := btcec.PrivKeyFromBytes(btcec.S256(), big.NewInt(1).Bytes())
+	hash := sha256.Sum256([]byte("Satoshi Nakamoto"))
+
+	r1, _, _ := SignECDSA(priv.ToECDSA(), hash[:], sha256.New, 1)
+	r2, _, _ := SignECDSA(priv.ToECDSA(), hash[:], sha256.New, 1)
+	r0, _, _ := SignECDSA(priv.ToECDSA(), hash[:], sha256.New, 0)
+	if r1.Cmp(r2) != 0 {
+		t.Error("the same nonce gives different signatures")
+	}
+	if r1.Cmp(r0) == 0 {
+		t.Error("the nonce does not change the signature")
+	}
+}
@@ -0,0 +1,83 @@
+package transactions
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/thanhxeon2470/beowulf-go/config"
+	"github.com/thanhxeon2470/beowulf-go/encoding/wif"
+	"github.com/thanhxeon2470/beowulf-go/types"
+
+	"github.com/btcsuite/btcd/btcec"
+)
+
+const signTestWIF = "5JWHY5DxTF6qN5grTtChDCYBmWHfY9zaSsw4CxEKN5eZpH9iBma"
+
+var compactVectors = []struct {
+	msg string
+	sig string
+}{
+	{"beowulf", "1f470023a7b5811178ad91057f5434cfb562be6e65167e8f583589cfb71deed0817ffe483b07722dda7dcb099814b69a23836f3cefdc06b3fd4e73c4eabb74eb5c"},
+	{"", "1f36cf2d873539ced8a80bff7254608d8e7c33641e70bf40437e048b071094d59a12dad863526ad783dde599a472fe1bc625fdb80943b460b3ea6818bc34483656"},
+	{"transaction", "2005ec2beb73378719b624636fb9334415710b45bea22c68630ae04191ea27e862595b034512a26d087adc1bd33c54219ff69da07405c99528b0ff97272eb1ca93"},
+}
+
+func TestSignBufferSha256Vectors(t *testing.T) {
+	privKey, err := wif.Decode(signTestWIF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	priv, pub := btcec.PrivKeyFromBytes(btcec.S256(), privKey)
+
+	for _, v := range compactVectors {
+		digest := sha256.Sum256([]byte(v.msg))
+		sig, err := signBufferSha256(digest[:], priv.ToECDSA())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(sig); got != v.sig {
+			t.Errorf("%q: signature %s, want %s", v.msg, got, v.sig)
+			continue
+		}
+
+		want, _ := hex.DecodeString(v.sig)
+		if sig[0] != want[0] {
+			t.Errorf("%q: recovery byte %d, want %d", v.msg, sig[0], want[0])
+		}
+		recovered, compressed, err := btcec.RecoverCompact(btcec.S256(), sig, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !compressed || !recovered.IsEqual(pub) {
+			t.Errorf("%q: signature does not recover to the key", v.msg)
+		}
+	}
+}
+
+func TestSignDeterministic(t *testing.T) {
+	privKey, _ := wif.Decode(signTestWIF)
+	created := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	sign := func() []string {
+		tx, err := NewBuilder(config.CHAIN_ID_TESTNET).
+			RefBlock(1, "0000000100000000").
+			Expiration(created.Add(time.Hour)).
+			CreatedTime(created).
+			Operations(&types.TransferOperation{From: "alice", To: "bob", Amount: types.MustParseAsset("1.00000 BWF"), Fee: types.MustParseAsset("0.01000 W")}).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tx.Sign([][]byte{privKey}, config.CHAIN_ID_TESTNET); err != nil {
+			t.Fatal(err)
+		}
+		return tx.Signatures
+	}
+
+	first, second := sign(), sign()
+	if len(first) != 1 || first[0] != second[0] {
+		t.Errorf("signatures differ: %v and %v", first, second)
+	}
+}
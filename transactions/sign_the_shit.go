@@ -8,8 +8,6 @@ import (
 	secp256k1 "github.com/btcsuite/btcd/btcec"
 	"github.com/pkg/errors"
 	"math/big"
-	"math/rand"
-	"time"
 )
 
 //SignSingle signature of the transaction by one of the keys
@@ -40,9 +38,9 @@ func signBufferSha256(bufSha256 []byte, privateKey *ecdsa.PrivateKey) ([]byte, e
 	var bufSha256Clone = make([]byte, len(bufSha256))
 	copy(bufSha256Clone, bufSha256)
 
-	rand.Seed(time.Now().UnixNano())
-	nonce := int(rand.Int31())
-
+	// The signature is deterministic (RFC 6979), the nonce only changes k when the
+	// signature is not canonical, i.e. R or S would not be 32 bytes in DER.
+	nonce := 0
 	for {
 		r, s, err := rfc6979.SignECDSA(privateKey, bufSha256Clone, sha256.New, nonce)
 
@@ -50,6 +50,38 @@ func (tx *SignedTransaction) Serialize() ([]byte, error) {
 	return b.Bytes(), nil
 }
 
+//ID returns the transaction id the node gives the transaction, it does not depend on the signatures.
+func (tx *SignedTransaction) ID() (string, error) {
+	unsigned := *tx.Transaction
+	unsigned.Signatures = nil
+	raw, err := (&SignedTransaction{&unsigned}).Serialize()
+	if err != nil {
+		return "", err
+	}
+	digest := sha256.Sum256(raw)
+	return hex.EncodeToString(digest[:20]), nil
+}
+
+//Deserialize function restores a transaction from its serialized form
+func Deserialize(raw []byte) (*SignedTransaction, error) {
+	var tx types.Transaction
+	decoder := transaction.NewDecoder(bytes.NewReader(raw))
+
+	if err := decoder.Decode(&tx); err != nil {
+		return nil, err
+	}
+	return &SignedTransaction{&tx}, nil
+}
+
+//DeserializeHex function restores a transaction from a hex blob such as API.GetTransactionHex returns
+func DeserializeHex(s string) (*SignedTransaction, error) {
+	raw, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode transaction hex")
+	}
+	return Deserialize(raw)
+}
+
 //Digest function that returns a digest from a serialized transaction
 func (tx *SignedTransaction) Digest(chain string) ([]byte, error) {
 	var msgBuffer bytes.Buffer
@@ -81,66 +113,9 @@ func (tx *SignedTransaction) Digest(chain string) ([]byte, error) {
 
 //Sign function directly generating transaction signature, return transactionId
 func (tx *SignedTransaction) Sign(privKeys [][]byte, chain string) (string, error) {
-	var buf bytes.Buffer
-	chainid, errdec := hex.DecodeString(chain)
-	if errdec != nil {
-		return "", errdec
-	}
-
-	txRaw, err := tx.Serialize()
-	if err != nil {
-		return "", err
-	}
-
-	hashSha256 := sha256.Sum256(txRaw)
-	var txId = make([]byte, 20)
-	copy(txId, hashSha256[:20])
-	buf.Write(chainid)
-	buf.Write(txRaw)
-	data := buf.Bytes()
-
-	var sigsHex []string
-
-	for _, privB := range privKeys {
-		sigBytes, err := tx.SignSingle(privB, data)
-		if err != nil {
-			return "", err
-		}
-		sigsHex = append(sigsHex, hex.EncodeToString(sigBytes))
-	}
-
-	tx.Transaction.Signatures = sigsHex
-	txHex := hex.EncodeToString(txId)
-	return txHex, nil
+	return tx.SignWith(KeySigners(privKeys), chain)
 }
 
 func (tx *SignedTransaction) SignMulti(privKeys [][]byte, chain string) ([]string, error) {
-	var sigsHex []string
-	var buf bytes.Buffer
-	chainid, errdec := hex.DecodeString(chain)
-	if errdec != nil {
-		return sigsHex, errdec
-	}
-
-	txRaw, err := tx.Serialize()
-	if err != nil {
-		return sigsHex, err
-	}
-	hashSha256 := sha256.Sum256(txRaw)
-	var txId = make([]byte, 20)
-	copy(txId, hashSha256[:20])
-	buf.Write(chainid)
-	buf.Write(txRaw)
-	data := buf.Bytes()
-
-	for _, privB := range privKeys {
-		sigBytes, err := tx.SignSingle(privB, data)
-		if err != nil {
-			return sigsHex, err
-		}
-		sigsHex = append(sigsHex, hex.EncodeToString(sigBytes))
-	}
-
-	//tx.Transaction.Signatures = sigsHex
-	return sigsHex, nil
+	return tx.SignMultiWith(KeySigners(privKeys), chain)
 }
@@ -0,0 +1,130 @@
+//go:build !nosigning
+// +build !nosigning
+
+package transactions
+
+import (
+	// Stdlib
+	"crypto/sha256"
+	"encoding/hex"
+
+	// RPC
+	"github.com/thanhxeon2470/beowulf-go/encoding/wif"
+
+	// Vendor
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/pkg/errors"
+)
+
+//Signer signs transaction digests with one key, the key itself may live outside
+//the process, e.g. in an HSM, a KMS or a separate signer process.
+type Signer interface {
+	//PublicKey returns the public key in the 'BEO...' form.
+	PublicKey() string
+	//SignDigest signs the 32-byte sha256 digest and returns a 65-byte compact
+	//recoverable signature: the recovery byte followed by R and S.
+	SignDigest(digest []byte) ([]byte, error)
+}
+
+//KeySigner is a Signer holding the private key in memory
+type KeySigner struct {
+	priv *btcec.PrivateKey
+}
+
+//NewKeySigner returns a Signer for the raw 32-byte private key.
+func NewKeySigner(privKey []byte) *KeySigner {
+	priv, _ := btcec.PrivKeyFromBytes(btcec.S256(), privKey)
+	return &KeySigner{priv: priv}
+}
+
+//NewWIFSigner returns a Signer for the private key in WIF.
+func NewWIFSigner(wifKey string) (*KeySigner, error) {
+	privKey, err := wif.Decode(wifKey)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeySigner(privKey), nil
+}
+
+//KeySigners returns a Signer for each of the raw private keys.
+func KeySigners(privKeys [][]byte) []Signer {
+	signers := make([]Signer, 0, len(privKeys))
+	for _, privKey := range privKeys {
+		signers = append(signers, NewKeySigner(privKey))
+	}
+	return signers
+}
+
+//PublicKey returns the public key in the 'BEO...' form.
+func (signer *KeySigner) PublicKey() string {
+	return PublicKeyString(signer.priv.PubKey())
+}
+
+//SignDigest signs the digest with the private key.
+func (signer *KeySigner) SignDigest(digest []byte) ([]byte, error) {
+	if len(digest) != sha256.Size {
+		return nil, errors.Errorf("digest must be %d bytes, got %d", sha256.Size, len(digest))
+	}
+	return signBufferSha256(digest, signer.priv.ToECDSA())
+}
+
+// checkSignature makes sure a signature of a possibly remote signer recovers to its key
+func checkSignature(signer Signer, digest, sig []byte) error {
+	pub, _, err := btcec.RecoverCompact(btcec.S256(), sig, digest)
+	if err != nil {
+		return errors.Wrap(err, "invalid signature")
+	}
+	if key := PublicKeyString(pub); key != signer.PublicKey() {
+		return errors.Errorf("signature is made by %s instead of %s", key, signer.PublicKey())
+	}
+	return nil
+}
+
+// signingData returns the transaction id and the digest to sign, both ignore the signatures already on the transaction
+func (tx *SignedTransaction) signingData(chain string) (string, []byte, error) {
+	txId, err := tx.ID()
+	if err != nil {
+		return "", nil, err
+	}
+	digest, err := tx.SigningDigest(chain)
+	if err != nil {
+		return "", nil, err
+	}
+	return txId, digest, nil
+}
+
+//SignWith signs the transaction with the signers, replacing its signatures, and returns the transactionId
+func (tx *SignedTransaction) SignWith(signers []Signer, chain string) (string, error) {
+	txId, sigsHex, err := tx.signWith(signers, chain)
+	if err != nil {
+		return "", err
+	}
+	tx.Transaction.Signatures = sigsHex
+	return txId, nil
+}
+
+//SignMultiWith returns the signatures of the signers without adding them to the transaction
+func (tx *SignedTransaction) SignMultiWith(signers []Signer, chain string) ([]string, error) {
+	_, sigsHex, err := tx.signWith(signers, chain)
+	return sigsHex, err
+}
+
+func (tx *SignedTransaction) signWith(signers []Signer, chain string) (string, []string, error) {
+	txId, digest, err := tx.signingData(chain)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var sigsHex []string
+	for _, signer := range signers {
+		sigBytes, err := signer.SignDigest(digest)
+		if err != nil {
+			return "", nil, errors.Wrapf(err, "failed to sign with %s", signer.PublicKey())
+		}
+		if err := checkSignature(signer, digest, sigBytes); err != nil {
+			return "", nil, err
+		}
+		sigsHex = append(sigsHex, hex.EncodeToString(sigBytes))
+	}
+	return txId, sigsHex, nil
+}
@@ -0,0 +1,58 @@
+package transactions
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/thanhxeon2470/beowulf-go/config"
+	"github.com/thanhxeon2470/beowulf-go/types"
+)
+
+func TestRemoteSigner(t *testing.T) {
+	priv, key := newTestKey(t)
+	local := NewKeySigner(priv.Serialize())
+
+	client, server := net.Pipe()
+	served := make(chan error, 1)
+	go func() {
+		served <- ServeSigner(server, server, local)
+		server.Close()
+	}()
+
+	conn := NewSignerConn(client)
+	signers, err := conn.Signers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signers) != 1 || signers[0].PublicKey() != key {
+		t.Fatalf("unexpected signers %v", signers)
+	}
+
+	tx, err := NewBuilder(config.CHAIN_ID_TESTNET).
+		RefBlock(1, "0000000100000000").
+		Expiration(time.Now().Add(time.Hour)).
+		Operations(&types.TransferOperation{From: "alice", To: "bob", Amount: types.MustParseAsset("1.00000 BWF"), Fee: types.MustParseAsset("0.01000 W")}).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.SignWith(signers, config.CHAIN_ID_TESTNET); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := tx.Verify(config.CHAIN_ID_TESTNET)
+	if err != nil || len(keys) != 1 || keys[0] != key {
+		t.Fatalf("unexpected keys %v: %v", keys, err)
+	}
+
+	// a key the signer does not hold is refused
+	_, otherKey := newTestKey(t)
+	if _, err := tx.SignWith([]Signer{conn.Signer(otherKey)}, config.CHAIN_ID_TESTNET); err == nil {
+		t.Error("expected an error for an unknown key")
+	}
+
+	conn.Close()
+	if err := <-served; err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,173 @@
+//go:build !nosigning
+// +build !nosigning
+
+package transactions
+
+import (
+	// Stdlib
+	"bytes"
+	"encoding/hex"
+	"strings"
+
+	// RPC
+	"github.com/thanhxeon2470/beowulf-go/api"
+	"github.com/thanhxeon2470/beowulf-go/config"
+	"github.com/thanhxeon2470/beowulf-go/types"
+
+	// Vendor
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ripemd160"
+)
+
+var (
+	ErrNoSignatures         = errors.New("transaction has no signatures")
+	ErrAuthorityUnsatisfied = errors.New("signatures do not satisfy the authority")
+)
+
+//AccountLookup returns the account whose authority is nested in another one
+type AccountLookup func(name string) (*api.AccountInfo, error)
+
+//APIAccountLookup returns an AccountLookup fetching the accounts with API.GetAccounts.
+func APIAccountLookup(a *api.API) AccountLookup {
+	return func(name string) (*api.AccountInfo, error) {
+		accounts, err := a.GetAccounts(name)
+		if err != nil {
+			return nil, err
+		}
+		if len(*accounts) == 0 {
+			return nil, errors.Errorf("account %s not found", name)
+		}
+		return &(*accounts)[0], nil
+	}
+}
+
+//SigningDigest returns the digest the signatures sign: sha256 of the chain ID
+//followed by the transaction serialized without its signatures.
+func (tx *SignedTransaction) SigningDigest(chain string) ([]byte, error) {
+	unsigned := *tx.Transaction
+	unsigned.Signatures = nil
+	return (&SignedTransaction{&unsigned}).Digest(chain)
+}
+
+//Verify recovers the public keys that produced the signatures of the transaction,
+//in the order of the signatures.
+func (tx *SignedTransaction) Verify(chain string) ([]string, error) {
+	if len(tx.Signatures) == 0 {
+		return nil, ErrNoSignatures
+	}
+	digest, err := tx.SigningDigest(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(tx.Signatures))
+	for i, sigHex := range tx.Signatures {
+		sig, err := hex.DecodeString(sigHex)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode signature %d", i)
+		}
+		pub, _, err := btcec.RecoverCompact(btcec.S256(), sig, digest)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to recover the key of signature %d", i)
+		}
+		keys = append(keys, PublicKeyString(pub))
+	}
+	return keys, nil
+}
+
+//PublicKeyString returns the public key in the 'BEO...' form.
+func PublicKeyString(pub *btcec.PublicKey) string {
+	compressed := pub.SerializeCompressed()
+	chHash := ripemd160.New()
+	chHash.Write(compressed)
+	chs := chHash.Sum(nil)[:4]
+	return config.ADDRESS_PREFIX + base58.Encode(append(compressed, chs...))
+}
+
+//ParsePublicKey parses a public key in the 'BEO...' form.
+func ParsePublicKey(s string) (*btcec.PublicKey, error) {
+	if !strings.HasPrefix(s, config.ADDRESS_PREFIX) {
+		return nil, errors.Errorf("public key must start with %s: %q", config.ADDRESS_PREFIX, s)
+	}
+	raw := base58.Decode(strings.TrimPrefix(s, config.ADDRESS_PREFIX))
+	if len(raw) != 37 {
+		return nil, errors.Errorf("invalid public key length: %q", s)
+	}
+	compressed, chs := raw[:33], raw[33:]
+	chHash := ripemd160.New()
+	chHash.Write(compressed)
+	if !bytes.Equal(chHash.Sum(nil)[:4], chs) {
+		return nil, errors.Errorf("public key checksum mismatch: %q", s)
+	}
+	return btcec.ParsePubKey(compressed, btcec.S256())
+}
+
+//VerifyOwner checks that the keys satisfy the owner authority of the account:
+//the weights of the keys and of the satisfied nested accounts must reach the
+//weight threshold. Nested accounts are fetched with lookup and followed up to
+//config.MAX_SIG_CHECK_DEPTH levels.
+func VerifyOwner(keys []string, account *api.AccountInfo, lookup AccountLookup) error {
+	if account == nil || account.Owner == nil {
+		return errors.New("account has no owner authority")
+	}
+	present := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		present[key] = true
+	}
+
+	ok, err := satisfies(present, account.Owner, lookup, 0)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.Wrapf(ErrAuthorityUnsatisfied, "owner of %s", account.Name)
+	}
+	return nil
+}
+
+func satisfies(present map[string]bool, auth *types.Authority, lookup AccountLookup, depth int) (bool, error) {
+	var weight int64
+	threshold := int64(auth.WeightThreshold)
+	for key, w := range auth.KeyAuths {
+		if present[key] {
+			if weight += w; weight >= threshold {
+				return true, nil
+			}
+		}
+	}
+
+	if depth >= config.MAX_SIG_CHECK_DEPTH || lookup == nil {
+		return weight >= threshold, nil
+	}
+	for name, w := range auth.AccountAuths {
+		nested, err := lookup(name)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to get account %s", name)
+		}
+		if nested.Owner == nil {
+			continue
+		}
+		ok, err := satisfies(present, nested.Owner, lookup, depth+1)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			if weight += w; weight >= threshold {
+				return true, nil
+			}
+		}
+	}
+	return weight >= threshold, nil
+}
+
+//VerifyOwnerSignatures recovers the signing keys of the transaction and checks them
+//against the owner authority of the account, see VerifyOwner.
+func (tx *SignedTransaction) VerifyOwnerSignatures(chain string, account *api.AccountInfo, lookup AccountLookup) error {
+	keys, err := tx.Verify(chain)
+	if err != nil {
+		return err
+	}
+	return VerifyOwner(keys, account, lookup)
+}
@@ -0,0 +1,124 @@
+package transactions
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/thanhxeon2470/beowulf-go/api"
+	"github.com/thanhxeon2470/beowulf-go/config"
+	"github.com/thanhxeon2470/beowulf-go/types"
+)
+
+func newTestKey(t *testing.T) (*btcec.PrivateKey, string) {
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return priv, PublicKeyString(priv.PubKey())
+}
+
+func TestVerifyRecoversSigners(t *testing.T) {
+	alice, aliceKey := newTestKey(t)
+	carol, carolKey := newTestKey(t)
+	_, otherKey := newTestKey(t)
+
+	tx, err := NewBuilder(config.CHAIN_ID_TESTNET).
+		RefBlock(1, "0000000100000000").
+		Expiration(time.Now().Add(time.Hour)).
+		Operations(&types.TransferOperation{From: "alice", To: "bob", Amount: types.MustParseAsset("1.00000 BWF"), Fee: types.MustParseAsset("0.01000 W")}).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.Sign([][]byte{alice.Serialize(), carol.Serialize()}, config.CHAIN_ID_TESTNET); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := tx.Verify(config.CHAIN_ID_TESTNET)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 || keys[0] != aliceKey || keys[1] != carolKey {
+		t.Fatalf("unexpected keys %v, expected %s and %s", keys, aliceKey, carolKey)
+	}
+	if pub, err := ParsePublicKey(aliceKey); err != nil || !pub.IsEqual(alice.PubKey()) {
+		t.Errorf("failed to parse %s: %v", aliceKey, err)
+	}
+	if keys, _ := tx.Verify(config.CHAIN_ID_MAINNET); len(keys) > 0 && keys[0] == aliceKey {
+		t.Error("signature verified on the wrong chain")
+	}
+
+	accounts := map[string]*api.AccountInfo{
+		"carol": {Name: "carol", Owner: &types.Authority{WeightThreshold: 1, KeyAuths: types.StringInt64Map{carolKey: 1}}},
+		"deep1": {Name: "deep1", Owner: &types.Authority{WeightThreshold: 1, AccountAuths: types.StringInt64Map{"deep2": 1}}},
+		"deep2": {Name: "deep2", Owner: &types.Authority{WeightThreshold: 1, AccountAuths: types.StringInt64Map{"carol": 1}}},
+	}
+	lookup := func(name string) (*api.AccountInfo, error) {
+		return accounts[name], nil
+	}
+
+	tests := []struct {
+		name  string
+		owner *types.Authority
+		ok    bool
+	}{
+		{"single key", &types.Authority{WeightThreshold: 1, KeyAuths: types.StringInt64Map{aliceKey: 1}}, true},
+		{"key below threshold", &types.Authority{WeightThreshold: 2, KeyAuths: types.StringInt64Map{aliceKey: 1, otherKey: 1}}, false},
+		{"key and nested account", &types.Authority{WeightThreshold: 2, KeyAuths: types.StringInt64Map{aliceKey: 1}, AccountAuths: types.StringInt64Map{"carol": 1}}, true},
+		{"nested account too deep", &types.Authority{WeightThreshold: 1, AccountAuths: types.StringInt64Map{"deep1": 1}}, false},
+	}
+	for _, test := range tests {
+		err := tx.VerifyOwnerSignatures(config.CHAIN_ID_TESTNET, &api.AccountInfo{Name: "alice", Owner: test.owner}, lookup)
+		if test.ok && err != nil {
+			t.Errorf("%s: %v", test.name, err)
+		}
+		if !test.ok && !errors.Is(err, ErrAuthorityUnsatisfied) {
+			t.Errorf("%s: expected ErrAuthorityUnsatisfied, got %v", test.name, err)
+		}
+	}
+}
+
+func TestSignSignedTransaction(t *testing.T) {
+	alice, aliceKey := newTestKey(t)
+	bob, bobKey := newTestKey(t)
+
+	tx, err := NewBuilder(config.CHAIN_ID_TESTNET).
+		RefBlock(1, "0000000100000000").
+		Expiration(time.Now().Add(time.Hour)).
+		Operations(&types.TransferOperation{From: "alice", To: "bob", Amount: types.MustParseAsset("1.00000 BWF"), Fee: types.MustParseAsset("0.01000 W")}).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.SignWith([]Signer{NewKeySigner(alice.Serialize())}, config.CHAIN_ID_TESTNET); err != nil {
+		t.Fatal(err)
+	}
+	// bob signs the transaction alice already signed
+	sigs, err := tx.SignMultiWith([]Signer{NewKeySigner(bob.Serialize())}, config.CHAIN_ID_TESTNET)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Signatures = append(tx.Signatures, sigs...)
+
+	keys, err := tx.Verify(config.CHAIN_ID_TESTNET)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 || keys[0] != aliceKey || keys[1] != bobKey {
+		t.Fatalf("unexpected keys %v, expected %s and %s", keys, aliceKey, bobKey)
+	}
+
+	id, err := tx.ID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	txId, err := tx.SignWith([]Signer{NewKeySigner(bob.Serialize())}, config.CHAIN_ID_TESTNET)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if txId != id {
+		t.Errorf("transaction id %s, expected %s", txId, id)
+	}
+}
@@ -1,8 +1,12 @@
 package transports
 
 import (
+	"context"
 	"encoding/json"
 	"io"
+
+	"github.com/pkg/errors"
+	"github.com/thanhxeon2470/beowulf-go/types"
 )
 
 var (
@@ -12,6 +16,7 @@ var (
 //Caller interface for sending a request to a network transport
 type Caller interface {
 	Call(method string, args []interface{}, reply interface{}, scid string) error
+	CallContext(ctx context.Context, method string, args []interface{}, reply interface{}, scid string) error
 	SetCallback(api string, method string, callback func(raw json.RawMessage)) error
 }
 
@@ -20,3 +25,33 @@ type CallCloser interface {
 	Caller
 	io.Closer
 }
+
+//BatchElem is a single call of a JSON-RPC batch request.
+//Error is set after the batch is sent when the node answered this call with an error.
+type BatchElem struct {
+	Method string
+	Args   []interface{}
+	Reply  interface{}
+	Error  error
+}
+
+//BatchCaller interface for sending several requests as one JSON-RPC 2.0 batch.
+//The returned error is only set when the batch as a whole failed, per call errors
+//are reported in BatchElem.Error.
+type BatchCaller interface {
+	CallBatch(batch []BatchElem, scid string) error
+	CallBatchContext(ctx context.Context, batch []BatchElem, scid string) error
+}
+
+//DecodeBatchResult unpacks the result of a single batch response into reply.
+func DecodeBatchResult(resp types.RPCResponse, reply interface{}) error {
+	if resp.Error != nil {
+		return resp.Error
+	}
+	if resp.Result != nil && reply != nil {
+		if err := json.Unmarshal(*resp.Result, reply); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal rpc result: %+v", string(*resp.Result))
+		}
+	}
+	return nil
+}
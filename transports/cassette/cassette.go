@@ -0,0 +1,102 @@
+//Package cassette records the interactions of a transport with a node into a cassette file
+//and replays them, so that the tests of the client and api packages run without a node.
+package cassette
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/thanhxeon2470/beowulf-go/types"
+)
+
+// The kinds of the interactions of a cassette
+const (
+	KindCall     = "call"
+	KindCallback = "callback"
+	KindNotice   = "notice"
+)
+
+//Interaction is a call, the registration of a callback or a notice of a callback
+type Interaction struct {
+	Kind     string          `json:"kind"`
+	Method   string          `json:"method"`
+	API      string          `json:"api,omitempty"`
+	Args     json.RawMessage `json:"args,omitempty"`
+	Scid     string          `json:"scid,omitempty"`
+	Result   json.RawMessage `json:"result,omitempty"`
+	Error    *types.RPCError `json:"error,omitempty"`
+	Failure  string          `json:"failure,omitempty"`
+	Callback int             `json:"callback,omitempty"`
+}
+
+//Cassette is the sequence of interactions with a node. A call holds its args and its result,
+//the error answered by the node in Error or the error of the transport in Failure.
+//A notice holds the raw notice in Result and the index of the registration of its callback.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+//Load reads a cassette file written by Save.
+func Load(path string) (*Cassette, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, errors.Wrapf(err, "failed to read cassette %s", path)
+	}
+	return &cassette, nil
+}
+
+//Save writes the cassette to the file at path.
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// canonical encodes v as JSON with the keys of its objects sorted, so equal params compare equal
+func canonical(v interface{}) (json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+	return json.Marshal(decoded)
+}
+
+// methodName returns the method a call runs on the node, e.g. condenser_api.get_block or find
+func methodName(method string, args json.RawMessage) string {
+	var items []interface{}
+	if method != "call" || json.Unmarshal(args, &items) != nil {
+		return method
+	}
+	switch len(items) {
+	case 3:
+		apiID, _ := items[0].(string)
+		name, _ := items[1].(string)
+		return apiID + "." + name
+	case 2:
+		name, _ := items[0].(string)
+		return name
+	}
+	return method
+}
+
+// decode unpacks a recorded result into reply like the transports do
+func decode(result json.RawMessage, reply interface{}) error {
+	if len(result) == 0 || reply == nil {
+		return nil
+	}
+	if err := json.Unmarshal(result, reply); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal rpc result: %+v", string(result))
+	}
+	return nil
+}
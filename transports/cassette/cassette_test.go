@@ -0,0 +1,99 @@
+package cassette_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/thanhxeon2470/beowulf-go/api"
+	"github.com/thanhxeon2470/beowulf-go/mocknode"
+	"github.com/thanhxeon2470/beowulf-go/transports/cassette"
+	"github.com/thanhxeon2470/beowulf-go/transports/websocket"
+	"github.com/thanhxeon2470/beowulf-go/types"
+)
+
+// session runs the same calls against a recorder or a replayer
+func session(t *testing.T, a *api.API, produce func()) (*api.Block, *api.NFT) {
+	if _, err := a.GetDynamicGlobalProperties(); err != nil {
+		t.Fatal(err)
+	}
+	headers := make(chan *api.BlockHeader, 1)
+	err := a.SetBlockAppliedCallback(func(header *api.BlockHeader, err error) {
+		if err == nil {
+			headers <- header
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	produce()
+	select {
+	case <-headers:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no block applied notice")
+	}
+
+	block, err := a.GetBlock(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var definition api.NFT
+	if err := a.FindOne("nft", "nfts", api.Query{}.Eq("symbol", "ART"), &definition); err != nil {
+		t.Fatal(err)
+	}
+	_, err = a.GetTransaction("0000000000000000000000000000000000000000")
+	if _, ok := err.(*types.RPCError); !ok {
+		t.Errorf("unknown transaction: %v", err)
+	}
+	return block, &definition
+}
+
+func TestRecordReplay(t *testing.T) {
+	node := mocknode.New(mocknode.WithBlockInterval(0))
+	defer node.Close()
+	if err := node.Insert("nft", "nfts", map[string]interface{}{"symbol": "ART", "issuer": "alice"}); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	caller, err := websocket.NewTransport(node.WebsocketURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder := cassette.NewRecorder(caller, path)
+	recorded, _ := session(t, api.NewAPI(recorder), func() { node.ProduceBlock() })
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	tape, err := cassette.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayer := cassette.NewReplayer(tape)
+	defer replayer.Close()
+	block, definition := session(t, api.NewAPI(replayer), func() {})
+	if block.BlockId != recorded.BlockId || definition.Issuer != "alice" {
+		t.Errorf("replayed block %s, definition %+v", block.BlockId, definition)
+	}
+	if unplayed := replayer.Unplayed(); len(unplayed) != 0 {
+		t.Errorf("unplayed %+v", unplayed)
+	}
+
+	strict := cassette.NewReplayer(tape)
+	defer strict.Close()
+	if _, err := api.NewAPI(strict).GetBlock(2); errors.Cause(err) != cassette.ErrNoInteraction {
+		t.Errorf("out of order call: %v", err)
+	}
+
+	loose := cassette.NewReplayer(tape, cassette.WithMatching(cassette.Loose))
+	defer loose.Close()
+	block, err = api.NewAPI(loose).GetBlock(7)
+	if err != nil || block.BlockId != recorded.BlockId {
+		t.Errorf("loose match: %+v, %v", block, err)
+	}
+	if _, err := api.NewAPI(loose).GetBlock(7); errors.Cause(err) != cassette.ErrNoInteraction {
+		t.Errorf("replayed twice: %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package cassette
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/thanhxeon2470/beowulf-go/transports"
+	"github.com/thanhxeon2470/beowulf-go/types"
+)
+
+//Recorder is a transports.CallCloser recording the calls, callbacks and notices of the
+//transport it wraps. Batches are not recorded as such: api sends them call by call.
+type Recorder struct {
+	caller transports.CallCloser
+	path   string
+
+	mutex    sync.Mutex
+	cassette Cassette
+}
+
+//NewRecorder records the interactions of caller, Close saves them to the cassette file at path.
+func NewRecorder(caller transports.CallCloser, path string) *Recorder {
+	return &Recorder{caller: caller, path: path}
+}
+
+func (r *Recorder) add(interaction Interaction) int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	return len(r.cassette.Interactions) - 1
+}
+
+func (r *Recorder) Call(method string, args []interface{}, reply interface{}, scid string) error {
+	return r.CallContext(context.Background(), method, args, reply, scid)
+}
+
+//CallContext sends the call with the wrapped transport and records it, calls aborted by ctx are not recorded.
+func (r *Recorder) CallContext(ctx context.Context, method string, args []interface{}, reply interface{}, scid string) error {
+	data, err := canonical(args)
+	if err != nil {
+		return err
+	}
+	var result json.RawMessage
+	err = r.caller.CallContext(ctx, method, args, &result, scid)
+	if err != nil && ctx.Err() != nil {
+		return err
+	}
+
+	interaction := Interaction{Kind: KindCall, Method: method, Args: data, Scid: scid}
+	if rpcErr, ok := err.(*types.RPCError); ok {
+		interaction.Error = rpcErr
+	} else if err != nil {
+		interaction.Failure = err.Error()
+	} else {
+		interaction.Result = result
+	}
+	r.add(interaction)
+	if err != nil {
+		return err
+	}
+	return decode(result, reply)
+}
+
+//SetCallback sets the callback on the wrapped transport, its registration and notices are recorded.
+func (r *Recorder) SetCallback(api string, method string, notice func(raw json.RawMessage)) error {
+	index := r.add(Interaction{Kind: KindCallback, API: api, Method: method})
+	err := r.caller.SetCallback(api, method, func(raw json.RawMessage) {
+		r.add(Interaction{Kind: KindNotice, Method: method, Result: append(json.RawMessage(nil), raw...), Callback: index})
+		notice(raw)
+	})
+	if err != nil {
+		r.mutex.Lock()
+		r.cassette.Interactions[index].Failure = err.Error()
+		r.mutex.Unlock()
+	}
+	return err
+}
+
+//Cassette returns the interactions recorded so far.
+func (r *Recorder) Cassette() *Cassette {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return &Cassette{Interactions: append([]Interaction(nil), r.cassette.Interactions...)}
+}
+
+//Close closes the wrapped transport and saves the cassette.
+func (r *Recorder) Close() error {
+	err := r.caller.Close()
+	if serr := r.Cassette().Save(r.path); serr != nil {
+		return serr
+	}
+	return err
+}
@@ -0,0 +1,283 @@
+package cassette
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrNoInteraction = errors.New("no recorded interaction matches the call")
+	ErrClosed        = errors.New("replayer is closed")
+)
+
+//Matching is how the replayer finds the recorded interaction of a call
+type Matching int
+
+const (
+	//Strict replays the interactions in the recorded order, a call must have the method, scid and params
+	//of the next recorded call.
+	Strict Matching = iota
+	//Loose replays the first unplayed call with the method, scid and params of the call, or else the first
+	//unplayed call of the same node method, e.g. a broadcast whose transaction expires at another time.
+	Loose
+)
+
+//Option configures the Replayer created by NewReplayer
+type Option func(*Replayer)
+
+//WithMatching sets how calls are matched, Strict by default.
+func WithMatching(matching Matching) Option {
+	return func(r *Replayer) {
+		r.matching = matching
+	}
+}
+
+//Replayer is a transports.CallCloser answering the calls with the interactions of a cassette.
+//The notices recorded after an interaction are sent to their callback once it is replayed.
+type Replayer struct {
+	cassette *Cassette
+	matching Matching
+
+	mutex     sync.Mutex
+	played    []bool
+	next      int // the position of the next interaction with Strict matching
+	callbacks map[int]func(raw json.RawMessage)
+	queue     []notice
+
+	wake      chan struct{}
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+type notice struct {
+	callback func(raw json.RawMessage)
+	raw      json.RawMessage
+}
+
+//NewReplayer replays the cassette, e.g. one loaded with Load.
+func NewReplayer(cassette *Cassette, opts ...Option) *Replayer {
+	// the args are compared compacted, a saved cassette has them indented
+	compacted := &Cassette{Interactions: make([]Interaction, len(cassette.Interactions))}
+	for i, interaction := range cassette.Interactions {
+		if len(interaction.Args) > 0 {
+			var buf bytes.Buffer
+			if json.Compact(&buf, interaction.Args) == nil {
+				interaction.Args = buf.Bytes()
+			}
+		}
+		compacted.Interactions[i] = interaction
+	}
+	r := &Replayer{
+		cassette:  compacted,
+		played:    make([]bool, len(cassette.Interactions)),
+		callbacks: make(map[int]func(raw json.RawMessage)),
+		wake:      make(chan struct{}, 1),
+		closed:    make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	go r.deliver()
+	return r
+}
+
+func (r *Replayer) Call(method string, args []interface{}, reply interface{}, scid string) error {
+	return r.CallContext(context.Background(), method, args, reply, scid)
+}
+
+//CallContext answers the call with the matching recorded call, ErrNoInteraction when there is none.
+func (r *Replayer) CallContext(ctx context.Context, method string, args []interface{}, reply interface{}, scid string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	data, err := canonical(args)
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	i, err := r.matchCall(method, data, scid)
+	if err != nil {
+		r.mutex.Unlock()
+		return err
+	}
+	interaction := r.cassette.Interactions[i]
+	r.play(i)
+	r.mutex.Unlock()
+
+	if interaction.Error != nil {
+		rpcErr := *interaction.Error
+		return &rpcErr
+	}
+	if interaction.Failure != "" {
+		return errors.New(interaction.Failure)
+	}
+	return decode(interaction.Result, reply)
+}
+
+//SetCallback registers the callback in place of the matching recorded registration,
+//its recorded notices are sent to it as the interactions they follow are replayed.
+func (r *Replayer) SetCallback(api string, method string, callback func(raw json.RawMessage)) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	i, err := r.matchCallback(api, method)
+	if err != nil {
+		return err
+	}
+	r.callbacks[i] = callback
+	r.play(i)
+	if failure := r.cassette.Interactions[i].Failure; failure != "" {
+		return errors.New(failure)
+	}
+	return nil
+}
+
+func (r *Replayer) isClosed() bool {
+	select {
+	case <-r.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// nextInteraction returns the position of the next interaction with Strict matching, notices are skipped
+func (r *Replayer) nextInteraction() int {
+	for i := r.next; i < len(r.cassette.Interactions); i++ {
+		if r.cassette.Interactions[i].Kind != KindNotice {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *Replayer) matchCall(method string, args json.RawMessage, scid string) (int, error) {
+	if r.isClosed() {
+		return 0, ErrClosed
+	}
+	same := func(interaction Interaction) bool {
+		return interaction.Kind == KindCall && interaction.Method == method && interaction.Scid == scid &&
+			bytes.Equal(interaction.Args, args)
+	}
+	if r.matching == Strict {
+		i := r.nextInteraction()
+		if i < 0 || !same(r.cassette.Interactions[i]) {
+			return 0, errors.Wrapf(ErrNoInteraction, "%s %s", methodName(method, args), args)
+		}
+		r.next = i + 1
+		return i, nil
+	}
+
+	name := methodName(method, args)
+	similar := -1
+	for i, interaction := range r.cassette.Interactions {
+		if r.played[i] || interaction.Kind != KindCall {
+			continue
+		}
+		if same(interaction) {
+			return i, nil
+		}
+		if similar < 0 && interaction.Scid == scid && methodName(interaction.Method, interaction.Args) == name {
+			similar = i
+		}
+	}
+	if similar < 0 {
+		return 0, errors.Wrapf(ErrNoInteraction, "%s %s", name, args)
+	}
+	return similar, nil
+}
+
+func (r *Replayer) matchCallback(api, method string) (int, error) {
+	if r.isClosed() {
+		return 0, ErrClosed
+	}
+	same := func(interaction Interaction) bool {
+		return interaction.Kind == KindCallback && interaction.API == api && interaction.Method == method
+	}
+	if r.matching == Strict {
+		i := r.nextInteraction()
+		if i < 0 || !same(r.cassette.Interactions[i]) {
+			return 0, errors.Wrapf(ErrNoInteraction, "callback %s.%s", api, method)
+		}
+		r.next = i + 1
+		return i, nil
+	}
+	for i, interaction := range r.cassette.Interactions {
+		if !r.played[i] && same(interaction) {
+			return i, nil
+		}
+	}
+	return 0, errors.Wrapf(ErrNoInteraction, "callback %s.%s", api, method)
+}
+
+// play marks the interaction played and queues the notices recorded right after it
+func (r *Replayer) play(i int) {
+	r.played[i] = true
+	queued := false
+	for j := i + 1; j < len(r.cassette.Interactions); j++ {
+		interaction := r.cassette.Interactions[j]
+		if interaction.Kind != KindNotice {
+			break
+		}
+		callback := r.callbacks[interaction.Callback]
+		if r.played[j] || callback == nil {
+			continue
+		}
+		r.played[j] = true
+		r.queue = append(r.queue, notice{callback: callback, raw: interaction.Result})
+		queued = true
+	}
+	if queued {
+		select {
+		case r.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// deliver sends the queued notices in order, out of the calls like the transports do
+func (r *Replayer) deliver() {
+	for {
+		select {
+		case <-r.closed:
+			return
+		case <-r.wake:
+		}
+		for {
+			r.mutex.Lock()
+			if len(r.queue) == 0 || r.isClosed() {
+				r.mutex.Unlock()
+				break
+			}
+			n := r.queue[0]
+			r.queue = r.queue[1:]
+			r.mutex.Unlock()
+			n.callback(n.raw)
+		}
+	}
+}
+
+//Unplayed returns the recorded interactions that were not replayed yet.
+func (r *Replayer) Unplayed() []Interaction {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var unplayed []Interaction
+	for i, interaction := range r.cassette.Interactions {
+		if !r.played[i] {
+			unplayed = append(unplayed, interaction)
+		}
+	}
+	return unplayed
+}
+
+//Close stops replaying, the notices not sent yet are dropped.
+func (r *Replayer) Close() error {
+	r.closeOnce.Do(func() {
+		close(r.closed)
+	})
+	return nil
+}
@@ -0,0 +1,46 @@
+package transports
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/pkg/errors"
+	"github.com/thanhxeon2470/beowulf-go/types"
+)
+
+var (
+	ErrShutdown = errors.New("connection is shut down")
+)
+
+//StatusError is returned when the node answers a call with an HTTP status other than 200 OK
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d", e.StatusCode)
+}
+
+//IsChainError reports whether the node answered the call with an error, a *types.RPCError whose
+//kind is matched with errors.Is, e.g. types.ErrInsufficientBalance. The call was received by the node.
+func IsChainError(err error) bool {
+	var rpcErr *types.RPCError
+	return errors.As(err, &rpcErr)
+}
+
+//IsTransportError reports whether the call failed on its way to or from the node, e.g. a *StatusError,
+//a timeout or ErrShutdown, rather than being answered with a chain error. The node may still have
+//received the call.
+func IsTransportError(err error) bool {
+	return err != nil && !IsChainError(err) && !errors.Is(err, context.Canceled)
+}
+
+//IsTimeout reports whether the call timed out, with a deadline of its context or of the transport.
+func IsTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,449 @@
+package failover
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/thanhxeon2470/beowulf-go/api"
+	"github.com/thanhxeon2470/beowulf-go/transports"
+	"github.com/thanhxeon2470/beowulf-go/transports/http"
+	"github.com/thanhxeon2470/beowulf-go/transports/websocket"
+)
+
+const (
+	defaultCheckInterval = 10 * time.Second
+	defaultCheckTimeout  = 5 * time.Second
+	defaultMaxHeadAge    = 30 * time.Second
+)
+
+var (
+	ErrNoNodes       = errors.New("no node endpoints given")
+	ErrNoHealthyNode = errors.New("no healthy node available")
+	ErrNoCallbacks   = errors.New("no websocket node available for callbacks")
+	ErrNoBatchNode   = errors.New("no node available that supports batch requests")
+)
+
+//Transport sends every call to the healthiest of several nodes. Reads are retried
+//on the next node when a node fails, broadcasts are sent to a single node only.
+type Transport struct {
+	nodes []*node
+
+	checkInterval time.Duration
+	checkTimeout  time.Duration
+	maxHeadAge    time.Duration
+	maxAttempts   int
+
+	httpOptions      []http.Option
+	websocketOptions []websocket.Option
+
+	mutex  sync.RWMutex
+	closed chan struct{}
+	done   sync.WaitGroup
+}
+
+// node is a single endpoint, its health fields are guarded by Transport.mutex
+type node struct {
+	url    string
+	ws     bool
+	caller transports.CallCloser
+
+	healthy   bool
+	headBlock uint32
+	headTime  time.Time
+	latency   time.Duration
+	lastErr   error
+}
+
+//NodeStatus is the last known health of a node
+type NodeStatus struct {
+	URL       string
+	Healthy   bool
+	HeadBlock uint32
+	HeadTime  time.Time
+	Latency   time.Duration
+	Err       error
+}
+
+//Option configures the Transport created by NewTransport
+type Option func(*Transport)
+
+//WithHealthCheckInterval sets how often the nodes are checked.
+func WithHealthCheckInterval(interval time.Duration) Option {
+	return func(caller *Transport) {
+		caller.checkInterval = interval
+	}
+}
+
+//WithHealthCheckTimeout sets how long a node may take to answer a health check.
+func WithHealthCheckTimeout(timeout time.Duration) Option {
+	return func(caller *Transport) {
+		caller.checkTimeout = timeout
+	}
+}
+
+//WithMaxHeadAge marks a node unhealthy when its head block is older than age.
+func WithMaxHeadAge(age time.Duration) Option {
+	return func(caller *Transport) {
+		caller.maxHeadAge = age
+	}
+}
+
+//WithMaxAttempts limits on how many nodes a read is tried, 0 means all of them.
+func WithMaxAttempts(n int) Option {
+	return func(caller *Transport) {
+		caller.maxAttempts = n
+	}
+}
+
+//WithHTTPOptions passes the given options to the transports of the HTTP nodes.
+func WithHTTPOptions(opts ...http.Option) Option {
+	return func(caller *Transport) {
+		caller.httpOptions = append(caller.httpOptions, opts...)
+	}
+}
+
+//WithWebsocketOptions passes the given options to the transports of the websocket nodes.
+func WithWebsocketOptions(opts ...websocket.Option) Option {
+	return func(caller *Transport) {
+		caller.websocketOptions = append(caller.websocketOptions, opts...)
+	}
+}
+
+//NewTransport creates a transport over the given http(s) and ws(s) node URLs and
+//checks their health once before returning. Nodes that can not be reached yet are
+//connected again by the periodic health check.
+func NewTransport(urls []string, opts ...Option) (*Transport, error) {
+	if len(urls) == 0 {
+		return nil, ErrNoNodes
+	}
+
+	caller := &Transport{
+		checkInterval: defaultCheckInterval,
+		checkTimeout:  defaultCheckTimeout,
+		maxHeadAge:    defaultMaxHeadAge,
+		closed:        make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(caller)
+	}
+
+	for _, s := range urls {
+		u, err := url.Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		switch u.Scheme {
+		case "wss", "ws":
+			caller.nodes = append(caller.nodes, &node{url: s, ws: true})
+		case "https", "http":
+			caller.nodes = append(caller.nodes, &node{url: s})
+		default:
+			return nil, errors.Errorf("unsupported node url: %s", s)
+		}
+	}
+
+	caller.CheckHealth()
+	if caller.checkInterval > 0 {
+		caller.done.Add(1)
+		go caller.loop()
+	}
+	return caller, nil
+}
+
+func (caller *Transport) loop() {
+	defer caller.done.Done()
+	ticker := time.NewTicker(caller.checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-caller.closed:
+			return
+		case <-ticker.C:
+			caller.CheckHealth()
+		}
+	}
+}
+
+//CheckHealth calls GetDynamicGlobalProperties on every node and updates their health.
+func (caller *Transport) CheckHealth() {
+	var wg sync.WaitGroup
+	for _, n := range caller.nodes {
+		wg.Add(1)
+		go func(n *node) {
+			defer wg.Done()
+			caller.check(n)
+		}(n)
+	}
+	wg.Wait()
+}
+
+func (caller *Transport) check(n *node) {
+	cc, err := caller.connect(n)
+	if err != nil {
+		caller.markDown(n, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), caller.checkTimeout)
+	defer cancel()
+	start := time.Now()
+	props, err := api.NewAPI(cc).GetDynamicGlobalPropertiesContext(ctx)
+	latency := time.Since(start)
+	if err != nil {
+		caller.markDown(n, err)
+		return
+	}
+
+	var headTime time.Time
+	if props.Time != nil && props.Time.Time != nil {
+		headTime = *props.Time.Time
+	}
+
+	caller.mutex.Lock()
+	defer caller.mutex.Unlock()
+	n.headBlock = props.HeadBlockNumber
+	n.headTime = headTime
+	n.latency = latency
+	n.lastErr = nil
+	n.healthy = true
+	if caller.maxHeadAge > 0 && time.Since(headTime) > caller.maxHeadAge {
+		n.healthy = false
+		n.lastErr = errors.Errorf("head block %d is stale: %s", n.headBlock, headTime)
+	}
+}
+
+// connect returns the transport of the node, dialing it if needed
+func (caller *Transport) connect(n *node) (transports.CallCloser, error) {
+	caller.mutex.RLock()
+	cc := n.caller
+	caller.mutex.RUnlock()
+	if cc != nil {
+		return cc, nil
+	}
+
+	var err error
+	if n.ws {
+		cc, err = websocket.NewTransport(n.url, caller.websocketOptions...)
+	} else {
+		cc, err = http.NewTransport(n.url, caller.httpOptions...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	caller.mutex.Lock()
+	defer caller.mutex.Unlock()
+	select {
+	case <-caller.closed:
+		cc.Close()
+		return nil, websocket.ErrShutdown
+	default:
+	}
+	if n.caller != nil {
+		cc.Close()
+		return n.caller, nil
+	}
+	n.caller = cc
+	return cc, nil
+}
+
+func (caller *Transport) markDown(n *node, err error) {
+	caller.mutex.Lock()
+	defer caller.mutex.Unlock()
+	n.healthy = false
+	n.lastErr = err
+	if n.ws && n.caller != nil && errors.Is(err, websocket.ErrShutdown) {
+		// the websocket is dead, dial it again on the next check
+		n.caller.Close()
+		n.caller = nil
+	}
+}
+
+//Status returns the last known health of every node.
+func (caller *Transport) Status() []NodeStatus {
+	caller.mutex.RLock()
+	defer caller.mutex.RUnlock()
+	status := make([]NodeStatus, len(caller.nodes))
+	for i, n := range caller.nodes {
+		status[i] = NodeStatus{
+			URL:       n.url,
+			Healthy:   n.healthy,
+			HeadBlock: n.headBlock,
+			HeadTime:  n.headTime,
+			Latency:   n.latency,
+			Err:       n.lastErr,
+		}
+	}
+	return status
+}
+
+// candidate is a node with the transport it had when the nodes were ranked
+type candidate struct {
+	node   *node
+	caller transports.CallCloser
+}
+
+// ranked returns the connected healthy nodes, the most up to date and fastest first.
+// When no node is healthy all connected nodes are returned as a last resort.
+func (caller *Transport) ranked(wsOnly bool) []candidate {
+	caller.mutex.RLock()
+	defer caller.mutex.RUnlock()
+	nodes := make([]*node, 0, len(caller.nodes))
+	for _, n := range caller.nodes {
+		if n.healthy && n.caller != nil && (n.ws || !wsOnly) {
+			nodes = append(nodes, n)
+		}
+	}
+	if len(nodes) == 0 {
+		for _, n := range caller.nodes {
+			if n.caller != nil && (n.ws || !wsOnly) {
+				nodes = append(nodes, n)
+			}
+		}
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if nodes[i].headBlock != nodes[j].headBlock {
+			return nodes[i].headBlock > nodes[j].headBlock
+		}
+		return nodes[i].latency < nodes[j].latency
+	})
+
+	candidates := make([]candidate, len(nodes))
+	for i, n := range nodes {
+		candidates[i] = candidate{node: n, caller: n.caller}
+	}
+	return candidates
+}
+
+func (caller *Transport) Call(method string, args []interface{}, reply interface{}, scid string) error {
+	return caller.CallContext(context.Background(), method, args, reply, scid)
+}
+
+//CallContext sends the call to the healthiest node. A read that fails because of the
+//node is tried again on the next one, a broadcast is never sent twice.
+func (caller *Transport) CallContext(ctx context.Context, method string, args []interface{}, reply interface{}, scid string) error {
+	nodes := caller.ranked(false)
+	if len(nodes) == 0 {
+		return ErrNoHealthyNode
+	}
+	return caller.try(ctx, nodes, isBroadcast(method, args, scid), func(cc transports.CallCloser) error {
+		return cc.CallContext(ctx, method, args, reply, scid)
+	})
+}
+
+//CallBatch sends the batch to the healthiest node that supports batches.
+func (caller *Transport) CallBatch(batch []transports.BatchElem, scid string) error {
+	return caller.CallBatchContext(context.Background(), batch, scid)
+}
+
+//CallBatchContext is like CallBatch but the request is aborted when ctx is done.
+func (caller *Transport) CallBatchContext(ctx context.Context, batch []transports.BatchElem, scid string) error {
+	broadcast := false
+	for _, elem := range batch {
+		broadcast = broadcast || isBroadcast(elem.Method, elem.Args, scid)
+	}
+	// the nodes whose transport cannot send batches are skipped, they are not down
+	var nodes []candidate
+	for _, c := range caller.ranked(false) {
+		if _, ok := c.caller.(transports.BatchCaller); ok {
+			nodes = append(nodes, c)
+		}
+	}
+	if len(nodes) == 0 {
+		return ErrNoBatchNode
+	}
+	return caller.try(ctx, nodes, broadcast, func(cc transports.CallCloser) error {
+		for i := range batch {
+			batch[i].Error = nil
+		}
+		return cc.(transports.BatchCaller).CallBatchContext(ctx, batch, scid)
+	})
+}
+
+// try runs send on the ranked nodes until one of them answers
+func (caller *Transport) try(ctx context.Context, nodes []candidate, broadcast bool, send func(cc transports.CallCloser) error) error {
+	attempts := len(nodes)
+	if broadcast {
+		attempts = 1
+	} else if caller.maxAttempts > 0 && caller.maxAttempts < attempts {
+		attempts = caller.maxAttempts
+	}
+
+	var err error
+	for _, c := range nodes[:attempts] {
+		err = send(c.caller)
+		if err == nil || !retryable(ctx, err) {
+			return err
+		}
+		caller.markDown(c.node, err)
+	}
+	return err
+}
+
+//SetCallback registers the callback on the healthiest websocket node.
+func (caller *Transport) SetCallback(api string, method string, callback func(raw json.RawMessage)) error {
+	nodes := caller.ranked(true)
+	if len(nodes) == 0 {
+		return ErrNoCallbacks
+	}
+	return nodes[0].caller.SetCallback(api, method, callback)
+}
+
+//Close stops the health checks and closes the transports of all nodes.
+func (caller *Transport) Close() error {
+	caller.mutex.Lock()
+	select {
+	case <-caller.closed:
+		caller.mutex.Unlock()
+		return websocket.ErrShutdown
+	default:
+	}
+	close(caller.closed)
+	caller.mutex.Unlock()
+	caller.done.Wait()
+
+	caller.mutex.Lock()
+	defer caller.mutex.Unlock()
+	var err error
+	for _, n := range caller.nodes {
+		if n.caller == nil {
+			continue
+		}
+		if cerr := n.caller.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+		n.caller = nil
+		n.healthy = false
+	}
+	return err
+}
+
+// isBroadcast reports whether the call changes the chain state and must not be repeated
+func isBroadcast(method string, args []interface{}, scid string) bool {
+	if len(scid) > 0 {
+		return false
+	}
+	if strings.HasPrefix(method, "broadcast_") {
+		return true
+	}
+	if method == "call" && len(args) > 1 {
+		if m, ok := args[1].(string); ok {
+			return strings.HasPrefix(m, "broadcast_")
+		}
+	}
+	return false
+}
+
+// retryable reports whether the error comes from the node rather than from the request
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	return !transports.IsChainError(err)
+}
@@ -0,0 +1,108 @@
+package failover
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/thanhxeon2470/beowulf-go/transports"
+	"github.com/thanhxeon2470/beowulf-go/types"
+)
+
+// newNode starts a fake node at the given head block, every other call fails
+// with status 500 when broken is set
+func newNode(t *testing.T, head uint32, broken bool, calls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req types.RPCRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		params := req.Params.([]interface{})
+		var result interface{}
+		if params[1] == "get_dynamic_global_properties" {
+			result = map[string]interface{}{
+				"head_block_number": head,
+				"time":              time.Now().UTC().Format("2006-01-02T15:04:05"),
+			}
+		} else {
+			atomic.AddInt32(calls, 1)
+			if broken {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			result = "ok"
+		}
+		raw, _ := json.Marshal(result)
+		msg := json.RawMessage(raw)
+		json.NewEncoder(w).Encode(types.RPCResponse{ID: req.ID, Result: &msg})
+	}))
+}
+
+func TestTransportFailover(t *testing.T) {
+	var bestCalls, backupCalls int32
+	best := newNode(t, 200, true, &bestCalls)
+	defer best.Close()
+	backup := newNode(t, 190, false, &backupCalls)
+	defer backup.Close()
+
+	caller, err := NewTransport([]string{backup.URL, best.URL}, WithHealthCheckInterval(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer caller.Close()
+
+	// The read goes to the node with the freshest head first and is retried on the backup.
+	var reply string
+	if err := caller.Call("call", []interface{}{"condenser_api", "get_block", []uint32{1}}, &reply, ""); err != nil || reply != "ok" {
+		t.Fatalf("unexpected reply %q: %v", reply, err)
+	}
+	if bestCalls != 1 || backupCalls != 1 {
+		t.Errorf("expected one call per node, got %d and %d", bestCalls, backupCalls)
+	}
+
+	// A broadcast is never repeated on another node.
+	caller.CheckHealth()
+	err = caller.Call("call", []interface{}{"condenser_api", "broadcast_transaction", []interface{}{}}, &reply, "")
+	if err == nil {
+		t.Error("expected the broadcast to fail")
+	}
+	if bestCalls != 2 || backupCalls != 1 {
+		t.Errorf("broadcast was retried: %d and %d calls", bestCalls, backupCalls)
+	}
+}
+
+// plainCaller hides the batch methods of the transport it wraps
+type plainCaller struct {
+	transports.CallCloser
+}
+
+func TestTransportBatchSkipsPlainNodes(t *testing.T) {
+	var calls int32
+	srv := newNode(t, 200, false, &calls)
+	defer srv.Close()
+
+	caller, err := NewTransport([]string{srv.URL}, WithHealthCheckInterval(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer caller.Close()
+	caller.CheckHealth()
+	caller.mutex.Lock()
+	caller.nodes[0].caller = plainCaller{caller.nodes[0].caller}
+	caller.mutex.Unlock()
+
+	batch := []transports.BatchElem{{Method: "call", Args: []interface{}{"condenser_api", "get_block", []uint32{1}}}}
+	if err := caller.CallBatch(batch, ""); err != ErrNoBatchNode {
+		t.Errorf("expected ErrNoBatchNode, got %v", err)
+	}
+	if status := caller.Status(); !status[0].Healthy {
+		t.Errorf("the node was marked down: %+v", status[0])
+	}
+	var reply string
+	if err := caller.Call("call", []interface{}{"condenser_api", "get_block", []uint32{1}}, &reply, ""); err != nil || reply != "ok" {
+		t.Errorf("unexpected reply %q: %v", reply, err)
+	}
+}
@@ -2,93 +2,147 @@ package http
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"math"
+	"net"
 	"net/http"
-	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/thanhxeon2470/beowulf-go/config"
+	"github.com/thanhxeon2470/beowulf-go/transports"
 	"github.com/thanhxeon2470/beowulf-go/types"
 )
 
+//Transport is a JSON-RPC over HTTP transport, it is safe for concurrent use
+//and keeps a pool of keep-alive connections to the node.
 type Transport struct {
 	Url    string
-	client http.Client
+	client *http.Client
+	// the changes of the options to the client, applied after WithHTTPClient whatever the order
+	clientOpts []func(*http.Client)
 
 	requestID uint64
-	reqMutex  sync.Mutex
 }
 
-func NewTransport(url string) (*Transport, error) {
-	timeout := time.Duration(config.HTTP_CONNECTION_TIMEOUT_SECOND * time.Second)
+//Option configures the Transport created by NewTransport
+type Option func(*Transport)
 
-	return &Transport{
-		client: http.Client{
-			Timeout: timeout,
-		},
-		Url: url,
-	}, nil
+//WithHTTPClient uses a copy of the given http.Client instead of the default pooled one, its nil
+//Transport is http.DefaultTransport. The other options change the copy and a clone of its
+//*http.Transport whatever their order, never the client passed in.
+func WithHTTPClient(client *http.Client) Option {
+	return func(caller *Transport) {
+		c := *client
+		switch t := client.Transport.(type) {
+		case nil:
+			c.Transport = http.DefaultTransport.(*http.Transport).Clone()
+		case *http.Transport:
+			c.Transport = t.Clone()
+		}
+		caller.client = &c
+	}
 }
 
-func (caller *Transport) Call(method string, args []interface{}, reply interface{}, scid string) error {
-	caller.reqMutex.Lock()
-	defer caller.reqMutex.Unlock()
-
-	// increase request id
-	if caller.requestID == math.MaxUint64 {
-		caller.requestID = 0
+//WithTimeout sets the overall timeout of a single call.
+func WithTimeout(timeout time.Duration) Option {
+	return func(caller *Transport) {
+		caller.clientOpts = append(caller.clientOpts, func(c *http.Client) {
+			c.Timeout = timeout
+		})
 	}
-	caller.requestID++
-	var request = types.RPCRequest{}
-	if len(scid) > 0 {
-		methodStr := fmt.Sprintf("%v", args[0])
-		request = types.RPCRequest{
-			Method: methodStr,
-			JSON:   "2.0",
-			ID:     caller.requestID,
-			Params: args[1],
-		}
-	} else {
-		request = types.RPCRequest{
-			Method: method,
-			JSON:   "2.0",
-			ID:     caller.requestID,
-			Params: args,
-		}
+}
+
+//WithMaxIdleConns sets the size of the keep-alive pool across all hosts.
+func WithMaxIdleConns(n int) Option {
+	return withHTTPTransport(func(t *http.Transport) {
+		t.MaxIdleConns = n
+	})
+}
+
+//WithMaxIdleConnsPerHost sets the size of the keep-alive pool for the node host.
+func WithMaxIdleConnsPerHost(n int) Option {
+	return withHTTPTransport(func(t *http.Transport) {
+		t.MaxIdleConnsPerHost = n
+	})
+}
+
+//WithMaxConnsPerHost limits the number of connections to the node host, 0 means no limit.
+func WithMaxConnsPerHost(n int) Option {
+	return withHTTPTransport(func(t *http.Transport) {
+		t.MaxConnsPerHost = n
+	})
+}
+
+//WithIdleConnTimeout sets how long an idle keep-alive connection is kept in the pool.
+func WithIdleConnTimeout(timeout time.Duration) Option {
+	return withHTTPTransport(func(t *http.Transport) {
+		t.IdleConnTimeout = timeout
+	})
+}
+
+// withHTTPTransport changes the pool of the client, unless its Transport is not an *http.Transport
+func withHTTPTransport(set func(*http.Transport)) Option {
+	return func(caller *Transport) {
+		caller.clientOpts = append(caller.clientOpts, func(c *http.Client) {
+			if t, ok := c.Transport.(*http.Transport); ok {
+				set(t)
+			}
+		})
 	}
+}
 
-	reqBody, err := json.Marshal(request)
-	if err != nil {
-		return err
+func newHTTPTransport() *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          config.HTTP_MAX_IDLE_CONNS,
+		MaxIdleConnsPerHost:   config.HTTP_MAX_IDLE_CONNS_PER_HOST,
+		IdleConnTimeout:       config.HTTP_IDLE_CONN_TIMEOUT_SECOND * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
 	}
+}
 
-	//resp, err := caller.client.Post(caller.Url, "application/json", bytes.NewBuffer(reqBody))
-	req, err := http.NewRequest("POST", caller.Url, bytes.NewBuffer(reqBody))
-	if err != nil {
-		return err
+func NewTransport(url string, opts ...Option) (*Transport, error) {
+	timeout := time.Duration(config.HTTP_CONNECTION_TIMEOUT_SECOND * time.Second)
+
+	caller := &Transport{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: newHTTPTransport(),
+		},
+		Url: url,
 	}
-	req.Header.Set("Content-Type", "application/json")
-	if len(scid) > 0 {
-		req.Header.Set("scid", scid)
+	for _, opt := range opts {
+		opt(caller)
 	}
-	resp, err := caller.client.Do(req)
-
-	if err != nil {
-		return err
+	for _, opt := range caller.clientOpts {
+		opt(caller.client)
 	}
-	defer resp.Body.Close()
+	return caller, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
+func (caller *Transport) Call(method string, args []interface{}, reply interface{}, scid string) error {
+	return caller.CallContext(context.Background(), method, args, reply, scid)
+}
 
-	respBody, err := ioutil.ReadAll(resp.Body)
+//CallContext is like Call but the request is aborted when ctx is done.
+func (caller *Transport) CallContext(ctx context.Context, method string, args []interface{}, reply interface{}, scid string) error {
+	// increase request id
+	requestID := atomic.AddUint64(&caller.requestID, 1)
+	request := newRequest(requestID, method, args, scid)
+
+	respBody, err := caller.post(ctx, request, scid)
 	if err != nil {
-		return errors.Wrap(err, "failed to read body")
+		return err
 	}
 
 	var rpcResponse types.RPCResponse
@@ -109,14 +163,117 @@ func (caller *Transport) Call(method string, args []interface{}, reply interface
 	return nil
 }
 
+//CallBatch sends all calls of the batch in a single JSON-RPC 2.0 batch request.
+func (caller *Transport) CallBatch(batch []transports.BatchElem, scid string) error {
+	return caller.CallBatchContext(context.Background(), batch, scid)
+}
+
+//CallBatchContext is like CallBatch but the request is aborted when ctx is done.
+func (caller *Transport) CallBatchContext(ctx context.Context, batch []transports.BatchElem, scid string) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	requests := make([]types.RPCRequest, len(batch))
+	index := make(map[uint64]int, len(batch))
+	for i, elem := range batch {
+		requestID := atomic.AddUint64(&caller.requestID, 1)
+		requests[i] = newRequest(requestID, elem.Method, elem.Args, scid)
+		index[requestID] = i
+	}
+
+	respBody, err := caller.post(ctx, requests, scid)
+	if err != nil {
+		return err
+	}
+
+	var rpcResponses []types.RPCResponse
+	if err = json.Unmarshal(respBody, &rpcResponses); err != nil {
+		// Nodes without batch support answer with a single error object.
+		var rpcResponse types.RPCResponse
+		if json.Unmarshal(respBody, &rpcResponse) == nil && rpcResponse.Error != nil {
+			return rpcResponse.Error
+		}
+		return errors.Wrapf(err, "failed to unmarshal batch response: %+v", string(respBody))
+	}
+
+	answered := make([]bool, len(batch))
+	for _, rpcResponse := range rpcResponses {
+		i, ok := index[rpcResponse.ID]
+		if !ok || answered[i] {
+			continue
+		}
+		answered[i] = true
+		batch[i].Error = transports.DecodeBatchResult(rpcResponse, batch[i].Reply)
+	}
+	for i := range batch {
+		if !answered[i] {
+			batch[i].Error = errors.Errorf("no response for batch call %d: %s", i, batch[i].Method)
+		}
+	}
+	return nil
+}
+
 func (caller *Transport) SetCallback(api string, method string, notice func(args json.RawMessage)) error {
 	panic("not supported")
 }
 
+//Close releases the idle keep-alive connections of the pool.
 func (caller *Transport) Close() error {
+	caller.client.CloseIdleConnections()
 	return nil
 }
 
+func newRequest(requestID uint64, method string, args []interface{}, scid string) types.RPCRequest {
+	if len(scid) > 0 {
+		return types.RPCRequest{
+			Method: fmt.Sprintf("%v", args[0]),
+			JSON:   "2.0",
+			ID:     requestID,
+			Params: args[1],
+		}
+	}
+	return types.RPCRequest{
+		Method: method,
+		JSON:   "2.0",
+		ID:     requestID,
+		Params: args,
+	}
+}
+
+// post sends the JSON encoded body to the node and returns the raw response body.
+func (caller *Transport) post(ctx context.Context, body interface{}, scid string) ([]byte, error) {
+	reqBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", caller.Url, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(scid) > 0 {
+		req.Header.Set("scid", scid)
+	}
+	resp, err := caller.client.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &transports.StatusError{StatusCode: resp.StatusCode}
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read body")
+	}
+	return respBody, nil
+}
+
 func check(url string) error {
 	resp, err := http.Get(url)
 	if err != nil {
@@ -125,7 +282,7 @@ func check(url string) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return errors.Errorf("Error. URL: %s STATUS: %s\n", url, resp.StatusCode)
+		return errors.Errorf("Error. URL: %s STATUS: %d\n", url, resp.StatusCode)
 	}
 	return nil
 }
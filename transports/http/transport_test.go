@@ -0,0 +1,153 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/thanhxeon2470/beowulf-go/transports"
+	"github.com/thanhxeon2470/beowulf-go/types"
+)
+
+func TestTransportConcurrentCalls(t *testing.T) {
+	const n = 8
+	var arrived sync.WaitGroup
+	arrived.Add(n)
+	release := make(chan struct{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req types.RPCRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		arrived.Done()
+		<-release
+		result := json.RawMessage(`"pong"`)
+		json.NewEncoder(w).Encode(types.RPCResponse{ID: req.ID, Result: &result})
+	}))
+	defer srv.Close()
+
+	caller, _ := NewTransport(srv.URL, WithMaxIdleConnsPerHost(n))
+	defer caller.Close()
+
+	var done sync.WaitGroup
+	for i := 0; i < n; i++ {
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			var reply string
+			if err := caller.Call("call", []interface{}{"condenser_api", "ping", nil}, &reply, ""); err != nil || reply != "pong" {
+				t.Errorf("unexpected reply %q: %v", reply, err)
+			}
+		}()
+	}
+
+	// All requests must be in flight at the same time before any of them is answered.
+	arrived.Wait()
+	close(release)
+	done.Wait()
+}
+
+func TestTransportCallContextDeadline(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	caller, _ := NewTransport(srv.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var reply string
+	err := caller.CallContext(ctx, "call", []interface{}{"condenser_api", "ping", nil}, &reply, "")
+	if err == nil || ctx.Err() != context.DeadlineExceeded {
+		t.Errorf("expected deadline error, got %v", err)
+	}
+	if !transports.IsTimeout(err) || !transports.IsTransportError(err) {
+		t.Errorf("not a transport timeout: %v", err)
+	}
+}
+
+func TestTransportOptionsKeepHTTPClient(t *testing.T) {
+	pool := &http.Transport{MaxIdleConns: 7}
+	client := &http.Client{Timeout: time.Minute, Transport: pool}
+	caller, _ := NewTransport("http://127.0.0.1", WithHTTPClient(client), WithTimeout(time.Second), WithMaxIdleConns(3))
+
+	if client.Timeout != time.Minute || pool.MaxIdleConns != 7 {
+		t.Errorf("options changed the given client: timeout %v, max idle conns %d", client.Timeout, pool.MaxIdleConns)
+	}
+	if caller.client.Timeout != time.Second || caller.client.Transport.(*http.Transport).MaxIdleConns != 3 {
+		t.Errorf("options not applied: %+v", caller.client)
+	}
+
+	// the options before WithHTTPClient apply too, a nil Transport is a clone of the default one
+	maxIdleConns := http.DefaultTransport.(*http.Transport).MaxIdleConns
+	caller, _ = NewTransport("http://127.0.0.1", WithMaxIdleConns(3), WithHTTPClient(&http.Client{}))
+	if pool, ok := caller.client.Transport.(*http.Transport); !ok || pool == http.DefaultTransport || pool.MaxIdleConns != 3 {
+		t.Errorf("options not applied: %+v", caller.client.Transport)
+	}
+	if http.DefaultTransport.(*http.Transport).MaxIdleConns != maxIdleConns {
+		t.Error("options changed http.DefaultTransport")
+	}
+}
+
+func TestTransportStatusError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	caller, _ := NewTransport(srv.URL)
+	var reply string
+	err := caller.Call("call", []interface{}{"condenser_api", "ping", nil}, &reply, "")
+	var statusErr *transports.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusServiceUnavailable || transports.IsChainError(err) {
+		t.Errorf("expected status error, got %v", err)
+	}
+}
+
+func TestTransportCallBatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqs []types.RPCRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			t.Error(err)
+		}
+		// Answer in reverse order with an error for the second call.
+		resps := make([]types.RPCResponse, 0, len(reqs))
+		for i := len(reqs) - 1; i >= 0; i-- {
+			if i == 1 {
+				resps = append(resps, types.RPCResponse{ID: reqs[i].ID, Error: &types.RPCError{Code: -32000, Message: "boom"}})
+				continue
+			}
+			result, _ := json.Marshal(reqs[i].Params.([]interface{})[1])
+			raw := json.RawMessage(result)
+			resps = append(resps, types.RPCResponse{ID: reqs[i].ID, Result: &raw})
+		}
+		json.NewEncoder(w).Encode(resps)
+	}))
+	defer srv.Close()
+
+	caller, _ := NewTransport(srv.URL)
+	replies := make([]string, 3)
+	batch := make([]transports.BatchElem, len(replies))
+	for i, method := range []string{"a", "b", "c"} {
+		batch[i] = transports.BatchElem{Method: "call", Args: []interface{}{"condenser_api", method, nil}, Reply: &replies[i]}
+	}
+	if err := caller.CallBatch(batch, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if replies[0] != "a" || replies[2] != "c" || batch[0].Error != nil || batch[2].Error != nil {
+		t.Errorf("unexpected replies %q: %v, %v", replies, batch[0].Error, batch[2].Error)
+	}
+	if batch[1].Error == nil {
+		t.Error("expected an error for the second call")
+	}
+}
@@ -0,0 +1,168 @@
+package websocket
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	defaultMinDelay = 500 * time.Millisecond
+	defaultMaxDelay = 30 * time.Second
+)
+
+//State of the websocket connection reported to the state hook
+type State int
+
+const (
+	//StateConnected the connection is (re-)established
+	StateConnected State = iota
+	//StateDisconnected the connection is lost, the transport is reconnecting
+	StateDisconnected
+	//StateClosed the transport is closed or the connection is lost for good
+	StateClosed
+)
+
+func (state State) String() string {
+	switch state {
+	case StateConnected:
+		return "connected"
+	case StateDisconnected:
+		return "disconnected"
+	case StateClosed:
+		return "closed"
+	}
+	return fmt.Sprintf("State(%d)", int(state))
+}
+
+//DisconnectedError is returned for the calls that were pending or made while
+//the connection was lost and the transport was reconnecting.
+type DisconnectedError struct {
+	Err error
+}
+
+func (e *DisconnectedError) Error() string {
+	return "websocket disconnected: " + e.Err.Error()
+}
+
+//Unwrap returns the error that broke the connection.
+func (e *DisconnectedError) Unwrap() error {
+	return e.Err
+}
+
+//Option configures the Transport created by NewTransport
+type Option func(*Transport)
+
+//WithReconnect enables reconnecting when the connection is lost. The delay between
+//attempts starts at minDelay and doubles up to maxDelay, callbacks set with SetCallback
+//are registered again once the connection is back.
+func WithReconnect(minDelay, maxDelay time.Duration) Option {
+	return func(caller *Transport) {
+		caller.reconnect = true
+		if minDelay > 0 {
+			caller.minDelay = minDelay
+		}
+		if maxDelay >= caller.minDelay {
+			caller.maxDelay = maxDelay
+		} else {
+			caller.maxDelay = caller.minDelay
+		}
+	}
+}
+
+//WithStateHook sets a function called on every connection state change,
+//err is the reason of the change if any.
+func WithStateHook(hook func(state State, err error)) Option {
+	return func(caller *Transport) {
+		caller.onState = hook
+	}
+}
+
+func (caller *Transport) notify(state State, err error) {
+	if caller.onState != nil {
+		caller.onState(state, err)
+	}
+}
+
+// lost handles the end of the connection read by input
+func (caller *Transport) lost(conn *websocket.Conn, err error) {
+	caller.mutex.Lock()
+	if caller.closing || !caller.reconnect {
+		caller.mutex.Unlock()
+		caller.stop(err)
+		if !caller.closing {
+			caller.notify(StateClosed, err)
+		}
+		return
+	}
+
+	// fail fast the pending calls, their replies will never arrive
+	caller.outage = err
+	for seq, call := range caller.pending {
+		delete(caller.pending, seq)
+		call.Error = &DisconnectedError{Err: err}
+		call.Done <- true
+	}
+	caller.mutex.Unlock()
+	conn.Close()
+
+	caller.notify(StateDisconnected, err)
+	go caller.redial()
+}
+
+// redial dials the node with exponential backoff until it succeeds or the transport is closed
+func (caller *Transport) redial() {
+	delay := caller.minDelay
+	for {
+		select {
+		case <-caller.closed:
+			return
+		case <-time.After(delay):
+		}
+
+		ws, _, err := websocket.DefaultDialer.Dial(caller.url, nil)
+		if err != nil {
+			log.Println("reconnect:", err)
+			if delay *= 2; delay > caller.maxDelay {
+				delay = caller.maxDelay
+			}
+			continue
+		}
+
+		caller.reqMutex.Lock()
+		caller.mutex.Lock()
+		if caller.closing {
+			caller.mutex.Unlock()
+			caller.reqMutex.Unlock()
+			ws.Close()
+			return
+		}
+		caller.conn = ws
+		caller.outage = nil
+		caller.mutex.Unlock()
+		caller.reqMutex.Unlock()
+
+		caller.serve(ws)
+		caller.notify(StateConnected, nil)
+		caller.replay()
+		return
+	}
+}
+
+// replay registers again the callbacks of the previous connection
+func (caller *Transport) replay() {
+	caller.callbackMutex.Lock()
+	subs := make(map[uint64]*subscription, len(caller.callbacks))
+	for id, sub := range caller.callbacks {
+		subs[id] = sub
+	}
+	caller.callbackMutex.Unlock()
+
+	for id, sub := range subs {
+		if err := caller.subscribe(sub, id); err != nil {
+			log.Printf("reconnect: failed to set callback %s.%s: %v\n", sub.api, sub.method, err)
+		}
+	}
+}
@@ -1,11 +1,13 @@
 package websocket
 
 import (
+	"github.com/thanhxeon2470/beowulf-go/transports"
 	"github.com/thanhxeon2470/beowulf-go/types"
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/gorilla/websocket"
-	"github.com/pkg/errors"
 	"log"
 	"math"
 	"sync"
@@ -13,13 +15,14 @@ import (
 )
 
 var (
-	ErrShutdown = errors.New("connection is shut down")
+	ErrShutdown = transports.ErrShutdown
 	writeWait   = 10 * time.Second
 	pongWait    = 60 * time.Second
 	pingPeriod  = (pongWait * 9) / 10
 )
 
 type Transport struct {
+	url  string
 	conn *websocket.Conn
 
 	reqMutex  sync.Mutex
@@ -27,11 +30,17 @@ type Transport struct {
 	pending   map[uint64]*callRequest
 
 	callbackMutex sync.Mutex
-	callbackID    uint64
-	callbacks     map[uint64]func(args json.RawMessage)
+	callbacks     map[uint64]*subscription
 
-	closing  bool // user has called Close
-	shutdown bool // server has told us to stop
+	closing  bool  // user has called Close
+	shutdown bool  // server has told us to stop
+	outage   error // set while the connection is lost and being re-established
+
+	reconnect bool
+	minDelay  time.Duration
+	maxDelay  time.Duration
+	onState   func(state State, err error)
+	closed    chan struct{}
 
 	mutex sync.Mutex
 }
@@ -43,63 +52,81 @@ type callRequest struct {
 	Reply *json.RawMessage // reply message
 }
 
-func NewTransport(url string) (*Transport, error) {
+// Represent a callback registered with SetCallback, kept to be replayed after a reconnect
+type subscription struct {
+	api    string
+	method string
+	notice func(args json.RawMessage)
+}
+
+func NewTransport(url string, opts ...Option) (*Transport, error) {
 	ws, _, err := websocket.DefaultDialer.Dial(url, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	client := &Transport{
+		url:       url,
 		conn:      ws,
 		pending:   make(map[uint64]*callRequest),
-		callbacks: make(map[uint64]func(args json.RawMessage)),
+		callbacks: make(map[uint64]*subscription),
+		minDelay:  defaultMinDelay,
+		maxDelay:  defaultMaxDelay,
+		closed:    make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(client)
 	}
 
-	go ping(ws)
-	go client.input()
+	client.serve(ws)
 	return client, nil
 }
 
-func (caller *Transport) Call(method string, args []interface{}, reply interface{}, scid string) error {
-	caller.reqMutex.Lock()
-	defer caller.reqMutex.Unlock()
-
-	caller.mutex.Lock()
-	if caller.closing || caller.shutdown {
-		caller.mutex.Unlock()
-		return ErrShutdown
-	}
+// serve starts the reader and the keep-alive pings of a freshly dialed connection
+func (caller *Transport) serve(ws *websocket.Conn) {
+	done := make(chan struct{})
+	go ping(ws, done)
+	go func() {
+		defer close(done)
+		caller.input(ws)
+	}()
+}
 
-	// increase request id
-	if caller.requestID == math.MaxUint64 {
-		caller.requestID = 0
-	}
-	caller.requestID++
-	seq := caller.requestID
+func (caller *Transport) Call(method string, args []interface{}, reply interface{}, scid string) error {
+	return caller.CallContext(context.Background(), method, args, reply, scid)
+}
 
-	c := &callRequest{
-		Done: make(chan bool, 1),
+//CallContext is like Call but stops waiting for the reply and forgets the pending call when ctx is done.
+func (caller *Transport) CallContext(ctx context.Context, method string, args []interface{}, reply interface{}, scid string) error {
+	seqs, calls, err := caller.register(1)
+	if err != nil {
+		return err
 	}
-	caller.pending[seq] = c
-	caller.mutex.Unlock()
+	return caller.do(ctx, seqs[0], calls[0], method, args, reply)
+}
 
+// do sends the registered call and waits for its reply
+func (caller *Transport) do(ctx context.Context, seq uint64, c *callRequest, method string, args []interface{}, reply interface{}) error {
 	request := types.RPCRequest{
 		Method: method,
 		JSON:   "2.0",
-		ID:     caller.requestID,
+		ID:     seq,
 		Params: args,
 	}
 
 	// send Json Rcp request
-	if err := caller.WriteJSON(request); err != nil {
-		caller.mutex.Lock()
-		delete(caller.pending, seq)
-		caller.mutex.Unlock()
+	seqs := []uint64{seq}
+	if err := caller.send(ctx, request, seqs); err != nil {
 		return err
 	}
 
 	// wait for the call to complete
-	<-c.Done
+	select {
+	case <-c.Done:
+	case <-ctx.Done():
+		caller.forget(seqs)
+		return ctx.Err()
+	}
 	if c.Error != nil {
 		return c.Error
 	}
@@ -112,50 +139,171 @@ func (caller *Transport) Call(method string, args []interface{}, reply interface
 	return nil
 }
 
+//CallBatch sends all calls of the batch as a single JSON-RPC 2.0 batch message.
+func (caller *Transport) CallBatch(batch []transports.BatchElem, scid string) error {
+	return caller.CallBatchContext(context.Background(), batch, scid)
+}
+
+//CallBatchContext is like CallBatch but stops waiting for the replies and forgets
+//the pending calls when ctx is done.
+func (caller *Transport) CallBatchContext(ctx context.Context, batch []transports.BatchElem, scid string) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	seqs, calls, err := caller.register(len(batch))
+	if err != nil {
+		return err
+	}
+
+	requests := make([]types.RPCRequest, len(batch))
+	for i, elem := range batch {
+		requests[i] = types.RPCRequest{
+			Method: elem.Method,
+			JSON:   "2.0",
+			ID:     seqs[i],
+			Params: elem.Args,
+		}
+	}
+
+	// send Json Rcp batch request
+	if err := caller.send(ctx, requests, seqs); err != nil {
+		return err
+	}
+
+	// wait for every call of the batch to complete
+	for i, c := range calls {
+		select {
+		case <-c.Done:
+		case <-ctx.Done():
+			caller.forget(seqs)
+			return ctx.Err()
+		}
+		if c.Error != nil {
+			batch[i].Error = c.Error
+			continue
+		}
+		if c.Reply != nil && batch[i].Reply != nil {
+			if err := json.Unmarshal(*c.Reply, batch[i].Reply); err != nil {
+				batch[i].Error = err
+			}
+		}
+	}
+	return nil
+}
+
+// register allocates n request ids and adds their pending calls
+func (caller *Transport) register(n int) ([]uint64, []*callRequest, error) {
+	caller.mutex.Lock()
+	defer caller.mutex.Unlock()
+	if caller.closing || caller.shutdown {
+		return nil, nil, ErrShutdown
+	}
+	if caller.outage != nil {
+		return nil, nil, &DisconnectedError{Err: caller.outage}
+	}
+
+	seqs := make([]uint64, n)
+	calls := make([]*callRequest, n)
+	for i := range seqs {
+		// increase request id
+		if caller.requestID == math.MaxUint64 {
+			caller.requestID = 0
+		}
+		caller.requestID++
+		seqs[i] = caller.requestID
+		calls[i] = &callRequest{
+			Done: make(chan bool, 1),
+		}
+		caller.pending[seqs[i]] = calls[i]
+	}
+	return seqs, calls, nil
+}
+
+// forget removes the pending calls, late replies for them are dropped
+func (caller *Transport) forget(seqs []uint64) {
+	caller.mutex.Lock()
+	for _, seq := range seqs {
+		delete(caller.pending, seq)
+	}
+	caller.mutex.Unlock()
+}
+
+// send writes the request, the pending calls are forgotten if it could not be sent
+func (caller *Transport) send(ctx context.Context, v interface{}, seqs []uint64) error {
+	caller.reqMutex.Lock()
+	err := ctx.Err()
+	if err == nil {
+		err = caller.WriteJSON(v)
+	}
+	caller.reqMutex.Unlock()
+	if err != nil {
+		caller.forget(seqs)
+	}
+	return err
+}
+
 func (caller *Transport) SetCallback(api string, method string, notice func(args json.RawMessage)) error {
-	var ans map[string]interface{}
-	// increase callback id
-	caller.callbackMutex.Lock()
-	if caller.callbackID == math.MaxUint64 {
-		caller.callbackID = 0
+	return caller.subscribe(&subscription{api: api, method: method, notice: notice}, 0)
+}
+
+// subscribe registers the callback under the id of the request that sets it up,
+// the node sends the notices with this id. A previous id of the callback is dropped.
+func (caller *Transport) subscribe(sub *subscription, previous uint64) error {
+	seqs, calls, err := caller.register(1)
+	if err != nil {
+		return err
 	}
-	//caller.callbackID++
-	caller.callbackID = caller.requestID + 1
-	caller.callbacks[caller.callbackID] = notice
+	callbackID := seqs[0]
+
+	caller.callbackMutex.Lock()
+	delete(caller.callbacks, previous)
+	caller.callbacks[callbackID] = sub
 	caller.callbackMutex.Unlock()
 
-	return caller.Call("call", []interface{}{api, method, []interface{}{caller.callbackID}}, ans, "")
+	var ans json.RawMessage
+	err = caller.do(context.Background(), callbackID, calls[0], "call", []interface{}{sub.api, sub.method, []interface{}{callbackID}}, &ans)
+	if err != nil && previous == 0 {
+		// a callback being replayed is kept to be tried again after the next reconnect
+		caller.callbackMutex.Lock()
+		delete(caller.callbacks, callbackID)
+		caller.callbackMutex.Unlock()
+	}
+	return err
 }
 
-func (caller *Transport) input() {
-	caller.conn.SetPongHandler(func(string) error { _ = caller.conn.SetReadDeadline(time.Now().Add(pongWait)); return nil })
+func (caller *Transport) input(conn *websocket.Conn) {
+	conn.SetPongHandler(func(string) error { _ = conn.SetReadDeadline(time.Now().Add(pongWait)); return nil })
 	for {
-		_, message, err := caller.conn.ReadMessage()
+		_, message, err := conn.ReadMessage()
 		if err != nil {
-			caller.stop(err)
+			caller.lost(conn, err)
 			return
 		}
 
+		if trimmed := bytes.TrimSpace(message); len(trimmed) > 0 && trimmed[0] == '[' {
+			if err := caller.onBatchResponse(trimmed); err != nil {
+				caller.lost(conn, err)
+				return
+			}
+			continue
+		}
+
 		var response types.RPCResponse
 		if err := json.Unmarshal(message, &response); err != nil {
-			caller.stop(err)
+			caller.lost(conn, err)
 			return
 		} else {
-			if call, ok := caller.pending[response.ID]; ok {
+			if call := caller.takePending(response.ID); call != nil {
 				caller.onCallResponse(response, call)
 			} else {
 				//the message is not a pending call, but probably a callback notice
 				var incoming types.RPCIncoming
 				if err := json.Unmarshal(message, &incoming); err != nil {
-					caller.stop(err)
+					caller.lost(conn, err)
 					return
 				}
-				if _, ok := caller.callbacks[incoming.ID]; ok {
-					if err := caller.onNotice(incoming); err != nil {
-						caller.stop(err)
-						return
-					}
-				} else {
+				if err := caller.onNotice(incoming); err != nil {
 					log.Printf("protocol error: unknown message received: %+v\n", incoming)
 					log.Printf("Answer: %+v\n", string(message))
 				}
@@ -166,36 +314,64 @@ func (caller *Transport) input() {
 
 // Return pending clients and shutdown the client
 func (caller *Transport) stop(err error) {
-	caller.reqMutex.Lock()
+	caller.mutex.Lock()
 	caller.shutdown = true
-	for _, call := range caller.pending {
+	for seq, call := range caller.pending {
+		delete(caller.pending, seq)
 		call.Error = err
 		call.Done <- true
 	}
-	caller.reqMutex.Unlock()
+	caller.mutex.Unlock()
+}
+
+// takePending removes and returns the pending call with the given id, nil if there is none
+func (caller *Transport) takePending(id uint64) *callRequest {
+	caller.mutex.Lock()
+	defer caller.mutex.Unlock()
+	call, ok := caller.pending[id]
+	if !ok {
+		return nil
+	}
+	delete(caller.pending, id)
+	return call
 }
 
 // Call response handler
 func (caller *Transport) onCallResponse(response types.RPCResponse, call *callRequest) {
-	caller.mutex.Lock()
-	delete(caller.pending, response.ID)
 	if response.Error != nil {
 		call.Error = response.Error
 	}
 	call.Reply = response.Result
 	call.Done <- true
-	caller.mutex.Unlock()
+}
+
+// Batch response handler, every item of the array answers one pending call
+func (caller *Transport) onBatchResponse(message []byte) error {
+	var responses []types.RPCResponse
+	if err := json.Unmarshal(message, &responses); err != nil {
+		return err
+	}
+	for _, response := range responses {
+		if call := caller.takePending(response.ID); call != nil {
+			caller.onCallResponse(response, call)
+		} else {
+			log.Printf("protocol error: unknown batch response received: %+v\n", response)
+		}
+	}
+	return nil
 }
 
 // Incoming notice handler
 func (caller *Transport) onNotice(incoming types.RPCIncoming) error {
-	notice := caller.callbacks[incoming.ID]
-	if notice == nil {
+	caller.callbackMutex.Lock()
+	sub := caller.callbacks[incoming.ID]
+	caller.callbackMutex.Unlock()
+	if sub == nil {
 		return fmt.Errorf("callback %d is not registered", incoming.ID)
 	}
 
 	// invoke callback
-	notice(incoming.Result)
+	sub.notice(incoming.Result)
 
 	return nil
 }
@@ -209,15 +385,27 @@ func (caller *Transport) Close() error {
 		return ErrShutdown
 	}
 	caller.closing = true
+	close(caller.closed)
+	conn, outage := caller.conn, caller.outage
 	caller.mutex.Unlock()
-	return caller.conn.Close()
+	err := conn.Close()
+	caller.notify(StateClosed, nil)
+	if outage != nil {
+		// the connection is already gone
+		return nil
+	}
+	return err
 }
 
-func ping(ws *websocket.Conn) {
+func ping(ws *websocket.Conn, done <-chan struct{}) {
 	ticker := time.NewTicker(pingPeriod)
 	defer ticker.Stop()
 	for {
-		<-ticker.C
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
 		if err := ws.WriteControl(websocket.PingMessage, []byte{}, time.Now().Add(writeWait)); err != nil {
 			log.Println("ping:", err)
 		}
@@ -0,0 +1,138 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/thanhxeon2470/beowulf-go/types"
+)
+
+func TestTransportCallContextCancel(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		// Read requests but never answer them.
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	caller, err := NewTransport("ws" + strings.TrimPrefix(srv.URL, "http"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer caller.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var reply string
+	err = caller.CallContext(ctx, "call", []interface{}{"condenser_api", "ping", nil}, &reply, "")
+	if err != context.DeadlineExceeded {
+		t.Errorf("expected deadline error, got %v", err)
+	}
+
+	caller.mutex.Lock()
+	pending := len(caller.pending)
+	caller.mutex.Unlock()
+	if pending != 0 {
+		t.Errorf("expected no pending calls, got %d", pending)
+	}
+}
+
+func TestTransportReconnectReplaysCallbacks(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	conns := make(chan *websocket.Conn, 2)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		conns <- conn
+	}))
+	defer srv.Close()
+
+	// answer the next request on conn and return its id
+	answer := func(conn *websocket.Conn) uint64 {
+		var req types.RPCRequest
+		if err := conn.ReadJSON(&req); err != nil {
+			t.Error(err)
+		}
+		if err := conn.WriteJSON(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": nil}); err != nil {
+			t.Error(err)
+		}
+		return req.ID
+	}
+
+	states := make(chan State, 4)
+	caller, err := NewTransport("ws"+strings.TrimPrefix(srv.URL, "http"),
+		WithReconnect(10*time.Millisecond, 50*time.Millisecond),
+		WithStateHook(func(state State, err error) { states <- state }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer caller.Close()
+
+	notices := make(chan string, 1)
+	first := <-conns
+	go answer(first)
+	err = caller.SetCallback("condenser_api", "set_block_applied_callback", func(raw json.RawMessage) {
+		notices <- string(raw)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A call pending when the connection drops fails fast with a typed error.
+	pending := make(chan error, 1)
+	go func() {
+		var reply string
+		pending <- caller.Call("call", []interface{}{"condenser_api", "ping", nil}, &reply, "")
+	}()
+	var req types.RPCRequest
+	if err := first.ReadJSON(&req); err != nil {
+		t.Fatal(err)
+	}
+	first.Close()
+
+	var disconnected *DisconnectedError
+	if err := <-pending; !errors.As(err, &disconnected) {
+		t.Errorf("expected DisconnectedError, got %v", err)
+	}
+	if state := <-states; state != StateDisconnected {
+		t.Errorf("expected %v, got %v", StateDisconnected, state)
+	}
+
+	second := <-conns
+	defer second.Close()
+	if state := <-states; state != StateConnected {
+		t.Errorf("expected %v, got %v", StateConnected, state)
+	}
+	id := answer(second)
+	if err := second.WriteJSON(map[string]interface{}{"jsonrpc": "2.0", "id": id, "result": []string{"block"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case notice := <-notices:
+		if notice != `["block"]` {
+			t.Errorf("unexpected notice %s", notice)
+		}
+	case <-time.After(time.Second):
+		t.Error("callback was not replayed after reconnect")
+	}
+}
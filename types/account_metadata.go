@@ -81,3 +81,11 @@ func (op *AccountMetadata) MarshalTransaction(encoder *transaction.Encoder) erro
 	enc.EncodeString(str)
 	return enc.Err()
 }
+
+func (op *AccountMetadata) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	str, err := decoder.DecodeString()
+	if err != nil {
+		return err
+	}
+	return op.UnmarshalJSON([]byte(strconv.Quote(str)))
+}
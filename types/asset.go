@@ -3,28 +3,173 @@ package types
 import (
 	"github.com/thanhxeon2470/beowulf-go/encoding/transaction"
 	"encoding/json"
+	"math/big"
+	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/pkg/errors"
 )
 
-//Asset type from parameter JSON
+//MaxAssetPrecision is the largest precision whose scale still fits into int64.
+const MaxAssetPrecision = 18
+
+var (
+	ErrAssetSymbolMismatch = errors.New("asset symbols do not match")
+	ErrAssetOverflow       = errors.New("asset amount overflows int64")
+
+	assetPattern = regexp.MustCompile(`^(-?)([0-9]+)(?:\.([0-9]*))? ([A-Za-z0-9]+)$`)
+)
+
+//Asset is an exact fixed-point amount: Amount is counted in units of 10^-Precision Symbol.
 type Asset struct {
-	Amount float64
-	Symbol string
+	Amount    int64
+	Precision uint8
+	Symbol    string
 }
 
-type AssetSymbol struct {
-	Decimals  uint8  `json:"decimals"`
-	AssetName string `json:"name"`
+//NewAsset returns an Asset from its integer amount, precision and symbol.
+func NewAsset(amount int64, precision uint8, symbol string) *Asset {
+	return &Asset{Amount: amount, Precision: precision, Symbol: symbol}
 }
 
-type ExtensionJsonType struct {
-	Data string `json:"data"`
+//ParseAsset parses strings like '99.00000 SYMBOL', the precision is the number of decimals given.
+func ParseAsset(s string) (*Asset, error) {
+	m := assetPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return nil, errors.Errorf("Expecting amount like '99.00000 SYMBOL', got %q", s)
+	}
+	if len(m[3]) > MaxAssetPrecision {
+		return nil, errors.Errorf("asset precision is greater than %d: %q", MaxAssetPrecision, s)
+	}
+
+	amount, err := strconv.ParseInt(m[1]+m[2]+m[3], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse asset amount: %q", s)
+	}
+	return NewAsset(amount, uint8(len(m[3])), m[4]), nil
 }
 
-type ExtensionType struct {
-	Type  uint8             `json:"type"`
-	Value ExtensionJsonType `json:"value"`
+//MustParseAsset is like ParseAsset but panics if the string can not be parsed.
+func MustParseAsset(s string) *Asset {
+	asset, err := ParseAsset(s)
+	if err != nil {
+		panic(err)
+	}
+	return asset
+}
+
+func pow10(precision uint8) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(precision)), nil)
+}
+
+func toInt64(v *big.Int) (int64, error) {
+	if !v.IsInt64() {
+		return 0, ErrAssetOverflow
+	}
+	return v.Int64(), nil
+}
+
+//Rescale returns the asset converted to the given precision, truncating extra decimals.
+func (op *Asset) Rescale(precision uint8) (*Asset, error) {
+	if precision > MaxAssetPrecision {
+		return nil, errors.Errorf("asset precision is greater than %d", MaxAssetPrecision)
+	}
+	v := big.NewInt(op.Amount)
+	if precision >= op.Precision {
+		v.Mul(v, pow10(precision-op.Precision))
+	} else {
+		v.Quo(v, pow10(op.Precision-precision))
+	}
+	amount, err := toInt64(v)
+	if err != nil {
+		return nil, err
+	}
+	return NewAsset(amount, precision, op.Symbol), nil
+}
+
+// align brings both assets to the larger of the two precisions.
+func (op *Asset) align(other *Asset) (*big.Int, *big.Int, uint8, error) {
+	if op.Symbol != other.Symbol {
+		return nil, nil, 0, errors.Wrapf(ErrAssetSymbolMismatch, "%s and %s", op.Symbol, other.Symbol)
+	}
+	precision := op.Precision
+	if other.Precision > precision {
+		precision = other.Precision
+	}
+	a := new(big.Int).Mul(big.NewInt(op.Amount), pow10(precision-op.Precision))
+	b := new(big.Int).Mul(big.NewInt(other.Amount), pow10(precision-other.Precision))
+	return a, b, precision, nil
+}
+
+//Add returns op + other, both must have the same symbol.
+func (op *Asset) Add(other *Asset) (*Asset, error) {
+	a, b, precision, err := op.align(other)
+	if err != nil {
+		return nil, err
+	}
+	amount, err := toInt64(a.Add(a, b))
+	if err != nil {
+		return nil, err
+	}
+	return NewAsset(amount, precision, op.Symbol), nil
+}
+
+//Sub returns op - other, both must have the same symbol.
+func (op *Asset) Sub(other *Asset) (*Asset, error) {
+	a, b, precision, err := op.align(other)
+	if err != nil {
+		return nil, err
+	}
+	amount, err := toInt64(a.Sub(a, b))
+	if err != nil {
+		return nil, err
+	}
+	return NewAsset(amount, precision, op.Symbol), nil
+}
+
+//Cmp compares op and other and returns -1, 0 or +1, both must have the same symbol.
+func (op *Asset) Cmp(other *Asset) (int, error) {
+	a, b, _, err := op.align(other)
+	if err != nil {
+		return 0, err
+	}
+	return a.Cmp(b), nil
+}
+
+//MulRatio returns op * numerator / denominator, rounded toward zero.
+func (op *Asset) MulRatio(numerator, denominator int64) (*Asset, error) {
+	if denominator == 0 {
+		return nil, errors.New("asset ratio denominator is zero")
+	}
+	v := new(big.Int).Mul(big.NewInt(op.Amount), big.NewInt(numerator))
+	amount, err := toInt64(v.Quo(v, big.NewInt(denominator)))
+	if err != nil {
+		return nil, err
+	}
+	return NewAsset(amount, op.Precision, op.Symbol), nil
+}
+
+//IsZero reports whether the amount is zero.
+func (op *Asset) IsZero() bool {
+	return op.Amount == 0
+}
+
+//Sign returns -1, 0 or +1 depending on the sign of the amount.
+func (op *Asset) Sign() int {
+	switch {
+	case op.Amount < 0:
+		return -1
+	case op.Amount > 0:
+		return 1
+	}
+	return 0
+}
+
+//Float64 returns the approximate amount, use it for display only.
+func (op *Asset) Float64() float64 {
+	f, _ := new(big.Rat).SetFrac(big.NewInt(op.Amount), pow10(op.Precision)).Float64()
+	return f
 }
 
 //UnmarshalJSON unpacking the JSON parameter in the Asset type.
@@ -33,16 +178,12 @@ func (op *Asset) UnmarshalJSON(data []byte) error {
 	if errUnq != nil {
 		return errUnq
 	}
-	param := strings.Split(str, " ")
 
-	s, errpf := strconv.ParseFloat(param[0], 64)
-	if errpf != nil {
-		return errpf
+	asset, err := ParseAsset(str)
+	if err != nil {
+		return err
 	}
-
-	op.Amount = s
-	op.Symbol = param[1]
-
+	*op = *asset
 	return nil
 }
 
@@ -53,33 +194,65 @@ func (op *Asset) MarshalJSON() ([]byte, error) {
 
 //MarshalTransaction is a function of converting type Asset to bytes.
 func (op *Asset) MarshalTransaction(encoder *transaction.Encoder) error {
-	ans, err := json.Marshal(op)
-	if err != nil {
-		return err
+	if op == nil {
+		return errors.New("asset is not set")
 	}
+	return encoder.EncodeAsset(op.Amount, op.Precision, op.Symbol)
+}
 
-	str, err := strconv.Unquote(string(ans))
+//UnmarshalTransaction is a function of converting bytes to type Asset.
+func (op *Asset) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	amount, precision, symbol, err := decoder.DecodeAsset()
 	if err != nil {
 		return err
 	}
-	return encoder.EncodeMoney(str)
+	*op = Asset{Amount: amount, Precision: precision, Symbol: symbol}
+	return nil
 }
 
 //String function convert type Asset to string.
 func (op *Asset) String() string {
-	var ammf string
-	ammf = strconv.FormatFloat(op.Amount, 'f', 5, 64)
-	return ammf + " " + op.Symbol
+	return op.StringAmount() + " " + op.Symbol
 }
 
-//StringAmount function convert type Asset.Amount to string.
+//StringAmount function convert type Asset.Amount to string with Asset.Precision decimals.
 func (op *Asset) StringAmount() string {
-	return strconv.FormatFloat(op.Amount, 'f', 5, 64)
+	s := strconv.FormatInt(op.Amount, 10)
+	if op.Precision == 0 {
+		return s
+	}
+
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	precision := int(op.Precision)
+	if len(s) <= precision {
+		s = strings.Repeat("0", precision-len(s)+1) + s
+	}
+	s = s[:len(s)-precision] + "." + s[len(s)-precision:]
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
+
+type AssetSymbol struct {
+	Decimals  uint8  `json:"decimals"`
+	AssetName string `json:"name"`
+}
+
+type ExtensionJsonType struct {
+	Data string `json:"data"`
+}
+
+type ExtensionType struct {
+	Type  uint8             `json:"type"`
+	Value ExtensionJsonType `json:"value"`
 }
 
 //UnmarshalJSON unpacking the JSON parameter in the AssetSymbol type.
 func (op *AssetSymbol) UnmarshalJSON(data []byte) error {
-	var raw AssetSymbol
+	type rawAssetSymbol AssetSymbol
+	var raw rawAssetSymbol
 
 	str := string(data) //strconv.Unquote(string(data))
 	if str == "" {
@@ -118,8 +291,18 @@ func (op *AssetSymbol) MarshalTransaction(encoder *transaction.Encoder) error {
 	return enc.Err()
 }
 
+//UnmarshalTransaction is a function of converting bytes to type AssetSymbol.
+func (op *AssetSymbol) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	str, err := decoder.DecodeSymbol()
+	if err != nil {
+		return err
+	}
+	return op.UnmarshalJSON([]byte(str))
+}
+
 func (ext *ExtensionJsonType) UnmarshalJSON(data []byte) error {
-	var raw ExtensionJsonType
+	type rawExtensionJsonType ExtensionJsonType
+	var raw rawExtensionJsonType
 
 	str := string(data) //strconv.Unquote(string(data))
 	if str == "" {
@@ -169,3 +352,22 @@ func (ext *ExtensionType) MarshalTransaction(encoder *transaction.Encoder) error
 	enc.EncodeTExt(str)
 	return enc.Err()
 }
+
+//UnmarshalTransaction is a function of converting bytes to type ExtensionType.
+func (ext *ExtensionType) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	var kind uint8
+	if err := decoder.DecodeNumber(&kind); err != nil {
+		return err
+	}
+	ext.Type = kind
+	if uint16(kind) != ExtJsonType.Code() {
+		return nil
+	}
+
+	data, err := decoder.DecodeString()
+	if err != nil {
+		return err
+	}
+	ext.Value.Data = data
+	return nil
+}
@@ -0,0 +1,72 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestParseAsset(t *testing.T) {
+	data := []struct {
+		In        string
+		Amount    int64
+		Precision uint8
+		Symbol    string
+		Out       string
+	}{
+		{"1.00000 W", 100000, 5, "W", "1.00000 W"},
+		{"0.01000 W", 1000, 5, "W", "0.01000 W"},
+		{"1234567.123456789012 TOKEN", 1234567123456789012, 12, "TOKEN", "1234567.123456789012 TOKEN"},
+		{"42 NOPREC", 42, 0, "NOPREC", "42 NOPREC"},
+		{"-0.5 BWF", -5, 1, "BWF", "-0.5 BWF"},
+	}
+
+	for _, d := range data {
+		checkAsset(t, d.In, d.Amount, d.Precision, d.Symbol, d.Out)
+	}
+
+	for _, s := range []string{"", "1.0", "abc W", "1.0000000000000000000 W", "99999999999999999999 W"} {
+		if _, err := ParseAsset(s); err == nil {
+			t.Errorf("%q: expected error", s)
+		}
+	}
+}
+
+func checkAsset(t *testing.T, in string, amount int64, precision uint8, symbol, out string) {
+	asset, err := ParseAsset(in)
+	if err != nil {
+		t.Errorf("%q: %v", in, err)
+		return
+	}
+	if asset.Amount != amount || asset.Precision != precision || asset.Symbol != symbol {
+		t.Errorf("%q: got %+v", in, asset)
+	}
+	if asset.String() != out {
+		t.Errorf("%q: expected %v, got %v", in, out, asset.String())
+	}
+}
+
+func TestAssetArithmetic(t *testing.T) {
+	a := MustParseAsset("1.10000 W")
+	b := MustParseAsset("0.015 W")
+
+	sum, err := a.Add(b)
+	if err != nil || sum.String() != "1.11500 W" {
+		t.Errorf("Add: got %v, %v", sum, err)
+	}
+	diff, err := b.Sub(a)
+	if err != nil || diff.String() != "-1.08500 W" {
+		t.Errorf("Sub: got %v, %v", diff, err)
+	}
+	if cmp, err := a.Cmp(b); err != nil || cmp != 1 {
+		t.Errorf("Cmp: got %v, %v", cmp, err)
+	}
+	third, err := a.MulRatio(1, 3)
+	if err != nil || third.String() != "0.36666 W" {
+		t.Errorf("MulRatio: got %v, %v", third, err)
+	}
+	if _, err := a.Add(MustParseAsset("1.00000 BWF")); err == nil {
+		t.Errorf("Add: expected symbol mismatch")
+	}
+	if _, err := NewAsset(1<<62, 0, "W").MulRatio(4, 1); err != ErrAssetOverflow {
+		t.Errorf("MulRatio: expected overflow, got %v", err)
+	}
+}
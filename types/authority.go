@@ -29,3 +29,49 @@ func (auth *Authority) MarshalTransaction(encoder *transaction.Encoder) error {
 	}
 	return enc.Err()
 }
+
+//UnmarshalTransaction is a function of converting bytes to type Authority.
+func (auth *Authority) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	var threshold uint32
+	if err := decoder.DecodeNumber(&threshold); err != nil {
+		return err
+	}
+	auth.WeightThreshold = threshold
+
+	// decode AccountAuths as map[string]uint16
+	n, err := decoder.DecodeUVarint()
+	if err != nil {
+		return err
+	}
+	auth.AccountAuths = make(StringInt64Map, n)
+	for i := uint64(0); i < n; i++ {
+		var weight uint16
+		dec := transaction.NewRollingDecoder(decoder)
+		k := ""
+		dec.DecodeString(&k)
+		dec.DecodeNumber(&weight)
+		if err := dec.Err(); err != nil {
+			return err
+		}
+		auth.AccountAuths[k] = int64(weight)
+	}
+
+	// decode KeyAuths as map[PubKey]uint16
+	n, err = decoder.DecodeUVarint()
+	if err != nil {
+		return err
+	}
+	auth.KeyAuths = make(StringInt64Map, n)
+	for i := uint64(0); i < n; i++ {
+		var weight uint16
+		dec := transaction.NewRollingDecoder(decoder)
+		k := ""
+		dec.DecodePubKey(&k)
+		dec.DecodeNumber(&weight)
+		if err := dec.Err(); err != nil {
+			return err
+		}
+		auth.KeyAuths[k] = int64(weight)
+	}
+	return nil
+}
@@ -9,7 +9,7 @@ type CheckSidechainOperation struct {
 	Committer   string `json:"committer"`
 	Csid        string `json:"csid"`
 	CsOperation string `json:"cs_operation"`
-	Fee         string `json:"fee"`
+	Fee         *Asset `json:"fee"`
 }
 
 //Type function that defines the type of operation SmartContractOperation.
@@ -31,8 +31,22 @@ func (op *CheckSidechainOperation) MarshalTransaction(encoder *transaction.Encod
 	enc.EncodeString(op.Committer)
 	enc.Encode(op.Csid)
 	enc.Encode(op.CsOperation)
-	enc.EncodeMoney(op.Fee)
+	enc.Encode(op.Fee)
 	//enc.Encode(op.Extensions)
 	//enc.EncodeUVarint(0)
 	return enc.Err()
 }
+
+//UnmarshalTransaction is a function of converting bytes to type CheckSidechainOperation.
+func (op *CheckSidechainOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	if err := decodeOpCode(decoder, TypeCheckSidechain); err != nil {
+		return err
+	}
+	op.Fee = &Asset{}
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.DecodeString(&op.Committer)
+	dec.DecodeString(&op.Csid)
+	dec.DecodeString(&op.CsOperation)
+	dec.Decode(op.Fee)
+	return dec.Err()
+}
@@ -45,6 +45,16 @@ func (num Int8) MarshalTransaction(encoder *transaction.Encoder) error {
 	return encoder.EncodeNumber(int(num))
 }
 
+//UnmarshalTransaction is a function of converting bytes to type Int8.
+func (num *Int8) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	var v int8
+	if err := decoder.DecodeNumber(&v); err != nil {
+		return err
+	}
+	*num = Int8(v)
+	return nil
+}
+
 //Int16 type from parameter JSON
 type Int16 int16
 
@@ -64,6 +74,16 @@ func (num Int16) MarshalTransaction(encoder *transaction.Encoder) error {
 	return encoder.EncodeNumber(int16(num))
 }
 
+//UnmarshalTransaction is a function of converting bytes to type Int16.
+func (num *Int16) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	var v int16
+	if err := decoder.DecodeNumber(&v); err != nil {
+		return err
+	}
+	*num = Int16(v)
+	return nil
+}
+
 //Int32 type from parameter JSON
 type Int32 int32
 
@@ -83,6 +103,16 @@ func (num Int32) MarshalTransaction(encoder *transaction.Encoder) error {
 	return encoder.EncodeNumber(int32(num))
 }
 
+//UnmarshalTransaction is a function of converting bytes to type Int32.
+func (num *Int32) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	var v int32
+	if err := decoder.DecodeNumber(&v); err != nil {
+		return err
+	}
+	*num = Int32(v)
+	return nil
+}
+
 //Int64 type from parameter JSON
 type Int64 int64
 
@@ -101,3 +131,13 @@ func (num *Int64) UnmarshalJSON(data []byte) error {
 func (num Int64) MarshalTransaction(encoder *transaction.Encoder) error {
 	return encoder.EncodeNumber(int64(num))
 }
+
+//UnmarshalTransaction is a function of converting bytes to type Int64.
+func (num *Int64) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	var v int64
+	if err := decoder.DecodeNumber(&v); err != nil {
+		return err
+	}
+	*num = Int64(v)
+	return nil
+}
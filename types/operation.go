@@ -8,6 +8,7 @@ import (
 
 	// Vendor
 	"github.com/pkg/errors"
+	"github.com/thanhxeon2470/beowulf-go/encoding/transaction"
 )
 
 // dataObjects keeps mapping operation type -> operation data object.
@@ -126,6 +127,46 @@ func (op *operationTuple) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+//DecodeOperation reads the next operation from the decoder based on its operation code.
+func DecodeOperation(decoder *transaction.Decoder) (Operation, error) {
+	code, err := decoder.PeekUVarint()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read operation code")
+	}
+	opType, ok := OpTypeFromCode(uint16(code))
+	if !ok {
+		return nil, errors.Errorf("unknown operation code: %d", code)
+	}
+	template, ok := dataObjects[opType]
+	if !ok {
+		return nil, errors.Errorf("unsupported operation type: %v", opType)
+	}
+
+	opData := reflect.New(
+		reflect.Indirect(reflect.ValueOf(template)).Type(),
+	).Interface().(Operation)
+
+	unmarshaller, ok := opData.(transaction.TransactionUnmarshaller)
+	if !ok {
+		return nil, errors.Errorf("operation %v can not be decoded", opType)
+	}
+	if err := unmarshaller.UnmarshalTransaction(decoder); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode operation %v", opType)
+	}
+	return opData, nil
+}
+
+func decodeOpCode(decoder *transaction.Decoder, kind OpType) error {
+	code, err := decoder.DecodeUVarint()
+	if err != nil {
+		return err
+	}
+	if uint16(code) != kind.Code() {
+		return errors.Errorf("unexpected operation code %d for %v", code, kind)
+	}
+	return nil
+}
+
 //JSONMarshal the function of packing with the processing of HTML tags.
 func JSONMarshal(t interface{}) ([]byte, error) {
 	buffer := &bytes.Buffer{}
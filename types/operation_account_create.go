@@ -6,7 +6,7 @@ import (
 
 //AccountCreateOperation represents account_create operation data.
 type AccountCreateOperation struct {
-	Fee            string     `json:"fee"`
+	Fee            *Asset     `json:"fee"`
 	Creator        string     `json:"creator"`
 	NewAccountName string     `json:"new_account_name"`
 	Owner          *Authority `json:"owner"`
@@ -27,10 +27,27 @@ func (op *AccountCreateOperation) Data() interface{} {
 func (op *AccountCreateOperation) MarshalTransaction(encoder *transaction.Encoder) error {
 	enc := transaction.NewRollingEncoder(encoder)
 	enc.EncodeUVarint(uint64(TypeAccountCreate.Code()))
-	enc.EncodeMoney(op.Fee)
+	enc.Encode(op.Fee)
 	enc.EncodeString(op.Creator)
 	enc.EncodeString(op.NewAccountName)
 	enc.Encode(op.Owner)
 	enc.Encode(op.JSONMetadata)
 	return enc.Err()
 }
+
+//UnmarshalTransaction is a function of converting bytes to type AccountCreateOperation.
+func (op *AccountCreateOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	if err := decodeOpCode(decoder, TypeAccountCreate); err != nil {
+		return err
+	}
+	op.Owner = &Authority{}
+	op.JSONMetadata = &AccountMetadata{}
+	op.Fee = &Asset{}
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(op.Fee)
+	dec.DecodeString(&op.Creator)
+	dec.DecodeString(&op.NewAccountName)
+	dec.Decode(op.Owner)
+	dec.Decode(op.JSONMetadata)
+	return dec.Err()
+}
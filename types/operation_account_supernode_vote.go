@@ -10,7 +10,7 @@ type AccountSupernodeVoteOperation struct {
 	Supernode 	string 	`json:"supernode"`
 	Approve 	bool   	`json:"approve"`
 	Votes		int64	`json:"votes"`
-	Fee         string  `json:"fee"`
+	Fee         *Asset  `json:"fee"`
 }
 
 //Type function that defines the type of operation AccountSupernodeVoteOperation.
@@ -31,6 +31,21 @@ func (op *AccountSupernodeVoteOperation) MarshalTransaction(encoder *transaction
 	enc.Encode(op.Supernode)
 	enc.EncodeBool(op.Approve)
 	enc.Encode(op.Votes)
-	enc.EncodeMoney(op.Fee)
+	enc.Encode(op.Fee)
 	return enc.Err()
 }
+
+//UnmarshalTransaction is a function of converting bytes to type AccountSupernodeVoteOperation.
+func (op *AccountSupernodeVoteOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	if err := decodeOpCode(decoder, TypeAccountSupernodeVote); err != nil {
+		return err
+	}
+	op.Fee = &Asset{}
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.DecodeString(&op.Account)
+	dec.DecodeString(&op.Supernode)
+	dec.DecodeBool(&op.Approve)
+	dec.DecodeNumber(&op.Votes)
+	dec.Decode(op.Fee)
+	return dec.Err()
+}
@@ -9,7 +9,7 @@ type AccountUpdateOperation struct {
 	Account      string     `json:"account"`
 	Owner        *Authority `json:"owner,omitempty"`
 	JSONMetadata *AccountMetadata     `json:"json_metadata"`
-	Fee          string     `json:"fee"`
+	Fee          *Asset     `json:"fee"`
 }
 
 //Type function that defines the type of operation AccountUpdateOperation.
@@ -34,6 +34,26 @@ func (op *AccountUpdateOperation) MarshalTransaction(encoder *transaction.Encode
 		enc.Encode(byte(0))
 	}
 	enc.Encode(op.JSONMetadata)
-	enc.EncodeMoney(op.Fee)
+	enc.Encode(op.Fee)
 	return enc.Err()
 }
+
+//UnmarshalTransaction is a function of converting bytes to type AccountUpdateOperation.
+func (op *AccountUpdateOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	if err := decodeOpCode(decoder, TypeAccountUpdate); err != nil {
+		return err
+	}
+	var hasOwner bool
+	op.JSONMetadata = &AccountMetadata{}
+	op.Fee = &Asset{}
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.DecodeString(&op.Account)
+	dec.DecodeBool(&hasOwner)
+	if hasOwner {
+		op.Owner = &Authority{}
+		dec.Decode(op.Owner)
+	}
+	dec.Decode(op.JSONMetadata)
+	dec.Decode(op.Fee)
+	return dec.Err()
+}
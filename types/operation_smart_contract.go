@@ -9,7 +9,7 @@ type SmartContractOperation struct {
 	RequiredOwners StringSlice `json:"required_owners"`
 	Scid           string      `json:"scid"`
 	ScOperation    string      `json:"sc_operation"`
-	Fee            string      `json:"fee"`
+	Fee            *Asset      `json:"fee"`
 }
 
 //Type function that defines the type of operation SmartContractOperation.
@@ -34,8 +34,24 @@ func (op *SmartContractOperation) MarshalTransaction(encoder *transaction.Encode
 	}
 	enc.Encode(op.Scid)
 	enc.Encode(op.ScOperation)
-	enc.EncodeMoney(op.Fee)
+	enc.Encode(op.Fee)
 	//enc.Encode(op.Extensions)
 	//enc.EncodeUVarint(0)
 	return enc.Err()
 }
+
+//UnmarshalTransaction is a function of converting bytes to type SmartContractOperation.
+func (op *SmartContractOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	if err := decodeOpCode(decoder, TypeSmartContract); err != nil {
+		return err
+	}
+	var owners []string
+	op.Fee = &Asset{}
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.DecodeArrString(&owners)
+	dec.DecodeString(&op.Scid)
+	dec.DecodeString(&op.ScOperation)
+	dec.Decode(op.Fee)
+	op.RequiredOwners = owners
+	return dec.Err()
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"github.com/pkg/errors"
 	"github.com/thanhxeon2470/beowulf-go/encoding/transaction"
 )
 
@@ -9,7 +10,7 @@ type SmtCreateOperation struct {
 	ControlAccount 	string 	`json:"control_account"`
 	Symbol			*AssetSymbol `json:"symbol"`
 	Creator			string	`json:"creator"`
-	SmtCreationFee  string  `json:"smt_creation_fee"`
+	SmtCreationFee  *Asset  `json:"smt_creation_fee"`
 	Precision		uint8   `json:"precision"`
 	Extensions      [][]interface{}      `json:"extensions"`
 	MaxSupply		uint64	`json:"max_supply"`
@@ -32,10 +33,36 @@ func (op *SmtCreateOperation) MarshalTransaction(encoder *transaction.Encoder) e
 	enc.Encode(op.ControlAccount)
 	enc.Encode(op.Symbol)
 	enc.Encode(op.Creator)
-	enc.EncodeMoney(op.SmtCreationFee)
+	enc.Encode(op.SmtCreationFee)
 	enc.Encode(op.Precision)
 	//enc.Encode(op.Extensions)
 	enc.EncodeUVarint(0)
 	enc.Encode(op.MaxSupply)
 	return enc.Err()
 }
+
+//UnmarshalTransaction is a function of converting bytes to type SmtCreateOperation.
+func (op *SmtCreateOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	if err := decodeOpCode(decoder, TypeSmtCreate); err != nil {
+		return err
+	}
+	var extensions uint64
+	op.Symbol = &AssetSymbol{}
+	op.SmtCreationFee = &Asset{}
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.DecodeString(&op.ControlAccount)
+	dec.Decode(op.Symbol)
+	dec.DecodeString(&op.Creator)
+	dec.Decode(op.SmtCreationFee)
+	dec.DecodeNumber(&op.Precision)
+	dec.DecodeUVarint(&extensions)
+	dec.DecodeNumber(&op.MaxSupply)
+	if err := dec.Err(); err != nil {
+		return err
+	}
+	if extensions != 0 {
+		return errors.Errorf("smt_create: unsupported extensions count %d", extensions)
+	}
+	op.Extensions = [][]interface{}{}
+	return nil
+}
@@ -8,7 +8,7 @@ import (
 type SupernodeUpdateOperation struct {
 	Owner           string           `json:"owner"`
 	BlockSigningKey string           `json:"block_signing_key"`
-	Fee             string           `json:"fee"`
+	Fee             *Asset           `json:"fee"`
 }
 
 //Type function that defines the type of operation SupernodeUpdateOperation.
@@ -27,6 +27,19 @@ func (op *SupernodeUpdateOperation) MarshalTransaction(encoder *transaction.Enco
 	enc.EncodeUVarint(uint64(TypeSupernodeUpdate.Code()))
 	enc.Encode(op.Owner)
 	enc.EncodePubKey(op.BlockSigningKey)
-	enc.EncodeMoney(op.Fee)
+	enc.Encode(op.Fee)
 	return enc.Err()
 }
+
+//UnmarshalTransaction is a function of converting bytes to type SupernodeUpdateOperation.
+func (op *SupernodeUpdateOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	if err := decodeOpCode(decoder, TypeSupernodeUpdate); err != nil {
+		return err
+	}
+	op.Fee = &Asset{}
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.DecodeString(&op.Owner)
+	dec.DecodePubKey(&op.BlockSigningKey)
+	dec.Decode(op.Fee)
+	return dec.Err()
+}
@@ -8,8 +8,8 @@ import (
 type TransferOperation struct {
 	From   string `json:"from"`
 	To     string `json:"to"`
-	Amount string `json:"amount"`
-	Fee    string `json:"fee"`
+	Amount *Asset `json:"amount"`
+	Fee    *Asset `json:"fee"`
 	Memo   string `json:"memo"`
 }
 
@@ -29,8 +29,23 @@ func (op *TransferOperation) MarshalTransaction(encoder *transaction.Encoder) er
 	enc.EncodeUVarint(uint64(TypeTransfer.Code()))
 	enc.Encode(op.From)
 	enc.Encode(op.To)
-	enc.EncodeMoney(op.Amount)
-	enc.EncodeMoney(op.Fee)
+	enc.Encode(op.Amount)
+	enc.Encode(op.Fee)
 	enc.Encode(op.Memo)
 	return enc.Err()
 }
+
+//UnmarshalTransaction is a function of converting bytes to type TransferOperation.
+func (op *TransferOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	if err := decodeOpCode(decoder, TypeTransfer); err != nil {
+		return err
+	}
+	op.Amount, op.Fee = &Asset{}, &Asset{}
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.DecodeString(&op.From)
+	dec.DecodeString(&op.To)
+	dec.Decode(op.Amount)
+	dec.Decode(op.Fee)
+	dec.DecodeString(&op.Memo)
+	return dec.Err()
+}
@@ -8,8 +8,8 @@ import (
 type TransferToVestingOperation struct {
 	From   string `json:"from"`
 	To     string `json:"to"`
-	Amount string `json:"amount"`
-	Fee    string `json:"fee"`
+	Amount *Asset `json:"amount"`
+	Fee    *Asset `json:"fee"`
 }
 
 //Type function that defines the type of operation TransferToVestingOperation.
@@ -28,7 +28,21 @@ func (op *TransferToVestingOperation) MarshalTransaction(encoder *transaction.En
 	enc.EncodeUVarint(uint64(TypeTransferToVesting.Code()))
 	enc.Encode(op.From)
 	enc.Encode(op.To)
-	enc.EncodeMoney(op.Amount)
-	enc.EncodeMoney(op.Fee)
+	enc.Encode(op.Amount)
+	enc.Encode(op.Fee)
 	return enc.Err()
 }
+
+//UnmarshalTransaction is a function of converting bytes to type TransferToVestingOperation.
+func (op *TransferToVestingOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	if err := decodeOpCode(decoder, TypeTransferToVesting); err != nil {
+		return err
+	}
+	op.Amount, op.Fee = &Asset{}, &Asset{}
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.DecodeString(&op.From)
+	dec.DecodeString(&op.To)
+	dec.Decode(op.Amount)
+	dec.Decode(op.Fee)
+	return dec.Err()
+}
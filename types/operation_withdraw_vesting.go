@@ -7,8 +7,8 @@ import (
 //WithdrawVestingOperation represents withdraw_vesting operation data.
 type WithdrawVestingOperation struct {
 	Account       string `json:"account"`
-	VestingShares string `json:"vesting_shares"`
-	Fee			  string `json:"fee"`
+	VestingShares *Asset `json:"vesting_shares"`
+	Fee			  *Asset `json:"fee"`
 }
 
 //Type function that defines the type of operation WithdrawVestingOperation.
@@ -26,7 +26,20 @@ func (op *WithdrawVestingOperation) MarshalTransaction(encoder *transaction.Enco
 	enc := transaction.NewRollingEncoder(encoder)
 	enc.EncodeUVarint(uint64(TypeWithdrawVesting.Code()))
 	enc.Encode(op.Account)
-	enc.EncodeMoney(op.VestingShares)
-	enc.EncodeMoney(op.Fee)
+	enc.Encode(op.VestingShares)
+	enc.Encode(op.Fee)
 	return enc.Err()
 }
+
+//UnmarshalTransaction is a function of converting bytes to type WithdrawVestingOperation.
+func (op *WithdrawVestingOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	if err := decodeOpCode(decoder, TypeWithdrawVesting); err != nil {
+		return err
+	}
+	op.VestingShares, op.Fee = &Asset{}, &Asset{}
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.DecodeString(&op.Account)
+	dec.Decode(op.VestingShares)
+	dec.Decode(op.Fee)
+	return dec.Err()
+}
@@ -47,6 +47,14 @@ var opTypes = [...]OpType{
 // opCodes keeps mapping operation type -> operation code.
 var opCodes map[OpType]uint16
 
+// OpTypeFromCode returns the operation type associated with the given operation code.
+func OpTypeFromCode(code uint16) (OpType, bool) {
+	if int(code) >= len(opTypes) {
+		return "", false
+	}
+	return opTypes[code], true
+}
+
 func init() {
 	opCodes = make(map[OpType]uint16, len(opTypes))
 	for i, opType := range opTypes {
@@ -2,7 +2,6 @@ package types
 
 import (
 	"encoding/json"
-	"fmt"
 )
 
 type RPCRequest struct {
@@ -47,7 +46,3 @@ type RPCIncoming struct {
 	JSON   string          `json:"jsonrpc"`
 	Result json.RawMessage `json:"result"`
 }
-
-func (e *RPCError) Error() string {
-	return fmt.Sprintf("%d: %s\n %#v", e.Code, e.Message, e.Data)
-}
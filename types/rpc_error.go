@@ -0,0 +1,131 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+//The kinds of the errors answered by the node, an *RPCError matches its kind with errors.Is:
+//
+//	if errors.Is(err, types.ErrInsufficientBalance) { ... }
+var (
+	ErrInsufficientBalance  = errors.New("insufficient balance")
+	ErrMissingAuthority     = errors.New("missing authority")
+	ErrTransactionExpired   = errors.New("transaction expired")
+	ErrDuplicateTransaction = errors.New("duplicate transaction")
+	ErrUnknownAccount       = errors.New("unknown account")
+	ErrAssertion            = errors.New("assertion failed")
+)
+
+//AssertionError is a failed FC_ASSERT of the node, taken from the stack of the RPCError:
+//
+//	var assertion *types.AssertionError
+//	if errors.As(err, &assertion) { fmt.Println(assertion.Expression, assertion.File, assertion.Line) }
+type AssertionError struct {
+	Expression string
+	Message    string
+	File       string
+	Line       int
+	Method     string
+}
+
+func (e *AssertionError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("assertion failed at %s:%d: %s", e.File, e.Line, e.Expression)
+	}
+	return fmt.Sprintf("assertion failed at %s:%d: %s: %s", e.File, e.Line, e.Expression, e.Message)
+}
+
+//Is matches ErrAssertion.
+func (e *AssertionError) Is(target error) bool {
+	return target == ErrAssertion
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("%d: %s", e.Code, e.Message)
+}
+
+//Is matches the kind of the error, e.g. ErrDuplicateTransaction.
+func (e *RPCError) Is(target error) bool {
+	return target != nil && target == e.Kind()
+}
+
+//Unwrap returns the *AssertionError of a failed assertion, nil otherwise.
+func (e *RPCError) Unwrap() error {
+	if assertion := e.Assertion(); assertion != nil {
+		return assertion
+	}
+	return nil
+}
+
+//Kind returns the kind of the error among the Err variables of the package, or nil when it is
+//another error, e.g. an unknown method. A failed assertion of a known kind, e.g. a transfer above
+//the balance, has that kind and still unwraps to its AssertionError.
+func (e *RPCError) Kind() error {
+	text := e.Message
+	for _, frame := range e.Data.Stack {
+		text += "\n" + frame.Format
+	}
+	expression := ""
+	if assertion := e.Assertion(); assertion != nil {
+		expression = assertion.Expression
+	}
+
+	switch {
+	case strings.Contains(text, "Duplicate transaction"):
+		return ErrDuplicateTransaction
+	case strings.HasPrefix(expression, "now < trx.expiration"), e.Data.Name == "transaction_expiration_exception":
+		return ErrTransactionExpired
+	case strings.HasPrefix(e.Data.Name, "tx_missing_"), strings.Contains(text, "Missing Owner Authority"),
+		strings.Contains(text, "Missing Active Authority"):
+		return ErrMissingAuthority
+	case strings.Contains(expression, "get_balance("), strings.Contains(text, "sufficient funds"),
+		strings.Contains(strings.ToLower(text), "insufficient balance"):
+		return ErrInsufficientBalance
+	case e.unknownAccount():
+		return ErrUnknownAccount
+	case expression != "":
+		return ErrAssertion
+	}
+	return nil
+}
+
+// unknownAccount reports whether the lookup of an account failed
+func (e *RPCError) unknownAccount() bool {
+	if e.Data.Message != "unknown key" {
+		return false
+	}
+	for _, frame := range e.Data.Stack {
+		if frame.Context.Method == "get_account" {
+			return true
+		}
+	}
+	return false
+}
+
+//Assertion returns the failed assertion from the stack of the error, nil if it is not an assert_exception.
+func (e *RPCError) Assertion() *AssertionError {
+	if e.Data.Name != "assert_exception" || len(e.Data.Stack) == 0 {
+		return nil
+	}
+	frame := e.Data.Stack[0]
+	// the format of an assertion is "expr: message"
+	expression, message := frame.Format, ""
+	if i := strings.Index(frame.Format, ": "); i >= 0 {
+		expression, message = frame.Format[:i], frame.Format[i+2:]
+	}
+	if data, ok := frame.Data.(map[string]interface{}); ok {
+		for key, value := range data {
+			message = strings.Replace(message, "${"+key+"}", fmt.Sprint(value), -1)
+		}
+	}
+	return &AssertionError{
+		Expression: expression,
+		Message:    message,
+		File:       frame.Context.File,
+		Line:       frame.Context.Line,
+		Method:     frame.Context.Method,
+	}
+}
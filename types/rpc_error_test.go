@@ -0,0 +1,72 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestRPCErrorKind(t *testing.T) {
+	tests := []struct {
+		raw  string
+		kind error
+	}{
+		{`{"code":-32000,"message":"Assert Exception:_db.get_balance( from_account, o.amount.symbol ) >= o.amount: Account does not have sufficient funds for transfer.",
+			"data":{"code":10,"name":"assert_exception","message":"Assert Exception","stack":[{"context":{"level":"error","file":"beowulf_evaluator.cpp","line":226,"method":"do_apply"},
+			"format":"_db.get_balance( from_account, o.amount.symbol ) >= o.amount: Account does not have sufficient funds for transfer.","data":{}}]}}`,
+			ErrInsufficientBalance},
+		{`{"code":-32000,"message":"missing required owner authority:Missing Owner Authority bob",
+			"data":{"code":3020000,"name":"tx_missing_owner_auth","message":"missing required owner authority","stack":[{"context":{"file":"authority_verification.hpp","line":94},
+			"format":"Missing Owner Authority ${id}","data":{"id":"bob"}}]}}`,
+			ErrMissingAuthority},
+		{`{"code":-32000,"message":"Assert Exception:now < trx.expiration: now: 2020-01-01T00:00:01 trx.exp: 2020-01-01T00:00:00",
+			"data":{"code":10,"name":"assert_exception","message":"Assert Exception","stack":[{"context":{"file":"database.cpp","line":3158},
+			"format":"now < trx.expiration: now: ${now} trx.exp: ${trx.expiration}","data":{"now":"2020-01-01T00:00:01","trx.expiration":"2020-01-01T00:00:00"}}]}}`,
+			ErrTransactionExpired},
+		{`{"code":-32000,"message":"Assert Exception:trx_idx.indices().get<by_trx_id>().find(trx_id) == trx_idx.indices().get<by_trx_id>().end(): Duplicate transaction check failed",
+			"data":{"code":10,"name":"assert_exception","message":"Assert Exception","stack":[{"context":{"file":"database.cpp","line":3163},
+			"format":"trx_idx.indices().get<by_trx_id>().find(trx_id) == trx_idx.indices().get<by_trx_id>().end(): Duplicate transaction check failed","data":{}}]}}`,
+			ErrDuplicateTransaction},
+		{`{"code":-32000,"message":"unknown key:unknown key: ",
+			"data":{"code":13,"name":"N5boost16exception_detail10clone_implINS0_19error_info_injectorISt12out_of_rangeEEEE","message":"unknown key",
+			"stack":[{"context":{"file":"exceptions.hpp","line":255,"method":"handle"},"format":"unknown key:unknown key: ","data":{}},
+			{"context":{"file":"database.cpp","line":523,"method":"get_account"},"format":"","data":{"name":"carol"}}]}}`,
+			ErrUnknownAccount},
+		{`{"code":-32000,"message":"Assert Exception:o.fee >= min_fee: Fee is not enough",
+			"data":{"code":10,"name":"assert_exception","message":"Assert Exception","stack":[{"context":{"file":"beowulf_operations.cpp","line":40},
+			"format":"o.fee >= min_fee: Fee is not enough","data":{}}]}}`,
+			ErrAssertion},
+		{`{"code":-32601,"message":"Could not find method get_nothing"}`, nil},
+	}
+
+	for _, test := range tests {
+		var rpcErr RPCError
+		if err := json.Unmarshal([]byte(test.raw), &rpcErr); err != nil {
+			t.Fatal(err)
+		}
+		var err error = errors.Wrap(&rpcErr, "broadcast")
+		if kind := rpcErr.Kind(); kind != test.kind {
+			t.Errorf("%s: kind %v, want %v", rpcErr.Message, kind, test.kind)
+		}
+		if test.kind != nil && !errors.Is(err, test.kind) {
+			t.Errorf("%s: errors.Is %v failed", rpcErr.Message, test.kind)
+		}
+	}
+
+	var rpcErr RPCError
+	if err := json.Unmarshal([]byte(tests[2].raw), &rpcErr); err != nil {
+		t.Fatal(err)
+	}
+	var assertion *AssertionError
+	if !errors.As(errors.Wrap(&rpcErr, "broadcast"), &assertion) || !errors.Is(&rpcErr, ErrAssertion) {
+		t.Fatalf("no assertion in %v", &rpcErr)
+	}
+	if assertion.Expression != "now < trx.expiration" || assertion.File != "database.cpp" || assertion.Line != 3158 ||
+		assertion.Message != "now: 2020-01-01T00:00:01 trx.exp: 2020-01-01T00:00:00" {
+		t.Errorf("assertion %+v", assertion)
+	}
+	if s := rpcErr.Error(); s != "-32000: "+rpcErr.Message {
+		t.Errorf("error %q", s)
+	}
+}
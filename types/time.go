@@ -31,3 +31,14 @@ func (t *Time) UnmarshalJSON(data []byte) error {
 func (t *Time) MarshalTransaction(encoder *transaction.Encoder) error {
 	return encoder.Encode(uint32(t.Time.Unix()))
 }
+
+//UnmarshalTransaction is a function of converting bytes to type Time.
+func (t *Time) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	var sec uint32
+	if err := decoder.DecodeNumber(&sec); err != nil {
+		return err
+	}
+	parsed := time.Unix(int64(sec), 0).UTC()
+	t.Time = &parsed
+	return nil
+}
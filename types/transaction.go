@@ -50,6 +50,73 @@ func (tx *Transaction) MarshalTransaction(encoder *transaction.Encoder) error {
 	return enc.Err()
 }
 
+// UnmarshalTransaction implements transaction.Unmarshaller interface.
+func (tx *Transaction) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	var (
+		refBlockNum    uint16
+		refBlockPrefix uint32
+		opsCount       uint64
+		extCount       uint64
+		createdTime    uint64
+	)
+	tx.Expiration = &Time{}
+
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.DecodeNumber(&refBlockNum)
+	dec.DecodeNumber(&refBlockPrefix)
+	dec.Decode(tx.Expiration)
+	dec.DecodeUVarint(&opsCount)
+	if err := dec.Err(); err != nil {
+		return err
+	}
+	if opsCount == 0 {
+		return errors.New("no operation specified")
+	}
+
+	tx.Operations = make(Operations, 0, opsCount)
+	for i := uint64(0); i < opsCount; i++ {
+		op, err := DecodeOperation(decoder)
+		if err != nil {
+			return err
+		}
+		tx.Operations = append(tx.Operations, op)
+	}
+
+	dec.DecodeUVarint(&extCount)
+	if err := dec.Err(); err != nil {
+		return err
+	}
+	tx.Extensions = make([]interface{}, 0, extCount)
+	for i := uint64(0); i < extCount; i++ {
+		ext := &ExtensionType{}
+		if err := ext.UnmarshalTransaction(decoder); err != nil {
+			return err
+		}
+		tx.Extensions = append(tx.Extensions, ext)
+	}
+
+	dec.DecodeNumber(&createdTime)
+	if err := dec.Err(); err != nil {
+		return err
+	}
+
+	// Signatures are written without a length prefix, so read until the end.
+	tx.Signatures = []string{}
+	for !decoder.EOF() {
+		var sig string
+		dec.DecodeString(&sig)
+		if err := dec.Err(); err != nil {
+			return err
+		}
+		tx.Signatures = append(tx.Signatures, sig)
+	}
+
+	tx.RefBlockNum = UInt16(refBlockNum)
+	tx.RefBlockPrefix = UInt32(refBlockPrefix)
+	tx.CreatedTime = UInt64(createdTime)
+	return nil
+}
+
 // PushOperation can be used to add an operation into the transaction.
 func (tx *Transaction) PushOperation(op Operation) {
 	tx.Operations = append(tx.Operations, op)
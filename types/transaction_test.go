@@ -0,0 +1,96 @@
+package types
+
+import (
+	// Stdlib
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	// RPC
+	"github.com/thanhxeon2470/beowulf-go/encoding/transaction"
+)
+
+const testPubKey = "BEO7jNh5ejQoqHqWcGWFJ1v4F5CzsG3EiBuz1VooCng1cH5QpJD27"
+
+var testOperations = []Operation{
+	&TransferOperation{From: "alice", To: "bob", Amount: MustParseAsset("1.50000 BWF"), Fee: MustParseAsset("0.01000 W"), Memo: "memo"},
+	&TransferToVestingOperation{From: "alice", To: "bob", Amount: MustParseAsset("10.00000 BWF"), Fee: MustParseAsset("0.01000 W")},
+	&WithdrawVestingOperation{Account: "alice", VestingShares: MustParseAsset("3.00000 M"), Fee: MustParseAsset("0.01000 W")},
+	&AccountCreateOperation{
+		Fee:            MustParseAsset("0.10000 W"),
+		Creator:        "alice",
+		NewAccountName: "carol",
+		Owner: &Authority{
+			WeightThreshold: 1,
+			AccountAuths:    StringInt64Map{"alice": 1},
+			KeyAuths:        StringInt64Map{testPubKey: 1},
+		},
+		JSONMetadata: &AccountMetadata{Profile: ProfileJSON{Name: "Carol"}},
+	},
+	&AccountUpdateOperation{Account: "alice", JSONMetadata: &AccountMetadata{}, Fee: MustParseAsset("0.01000 W")},
+	&SupernodeUpdateOperation{Owner: "alice", BlockSigningKey: testPubKey, Fee: MustParseAsset("0.01000 W")},
+	&AccountSupernodeVoteOperation{Account: "alice", Supernode: "bob", Approve: true, Votes: 42, Fee: MustParseAsset("0.01000 W")},
+	&SmtCreateOperation{
+		ControlAccount: "alice",
+		Symbol:         &AssetSymbol{Decimals: 8, AssetName: "TOKEN"},
+		Creator:        "alice",
+		SmtCreationFee: MustParseAsset("1.00000 W"),
+		Precision:      8,
+		Extensions:     [][]interface{}{},
+		MaxSupply:      1000000,
+	},
+	&SmartContractOperation{
+		RequiredOwners: StringSlice{"alice"},
+		Scid:           "s01",
+		ScOperation:    `{"contractName":"nft","contractAction":"burn"}`,
+		Fee:            MustParseAsset("0.01000 W"),
+	},
+	&CheckSidechainOperation{Committer: "alice", Csid: "s01", CsOperation: "{}", Fee: MustParseAsset("0.01000 W")},
+}
+
+func TestTransactionRoundTrip(t *testing.T) {
+	expiration := time.Unix(1600000000, 0).UTC()
+	for _, op := range testOperations {
+		tx := &Transaction{
+			RefBlockNum:    1234,
+			RefBlockPrefix: 567890,
+			Expiration:     &Time{&expiration},
+			Operations:     Operations{op},
+			Extensions: []interface{}{
+				&ExtensionType{Type: uint8(ExtJsonType.Code()), Value: ExtensionJsonType{Data: "ext"}},
+			},
+			CreatedTime: 1599999000,
+			Signatures:  []string{},
+		}
+
+		var b bytes.Buffer
+		if err := transaction.NewEncoder(&b).Encode(tx); err != nil {
+			t.Fatalf("%v: encode: %v", op.Type(), err)
+		}
+
+		var got Transaction
+		if err := transaction.NewDecoder(bytes.NewReader(b.Bytes())).Decode(&got); err != nil {
+			t.Fatalf("%v: decode: %v", op.Type(), err)
+		}
+
+		if !reflect.DeepEqual(tx.Operations, got.Operations) {
+			t.Errorf("%v: expected %+v, got %+v", op.Type(), tx.Operations[0], got.Operations[0])
+		}
+		if !reflect.DeepEqual(tx.Extensions, got.Extensions) {
+			t.Errorf("%v: expected extensions %+v, got %+v", op.Type(), tx.Extensions, got.Extensions)
+		}
+		if got.RefBlockNum != tx.RefBlockNum || got.RefBlockPrefix != tx.RefBlockPrefix ||
+			got.CreatedTime != tx.CreatedTime || !got.Expiration.Equal(*tx.Expiration.Time) {
+			t.Errorf("%v: header mismatch: %+v", op.Type(), got)
+		}
+
+		var again bytes.Buffer
+		if err := transaction.NewEncoder(&again).Encode(&got); err != nil {
+			t.Fatalf("%v: re-encode: %v", op.Type(), err)
+		}
+		if !bytes.Equal(b.Bytes(), again.Bytes()) {
+			t.Errorf("%v: re-encoded bytes differ", op.Type())
+		}
+	}
+}
@@ -64,6 +64,16 @@ func (num UInt8) MarshalTransaction(encoder *transaction.Encoder) error {
 	return encoder.EncodeNumber(uint8(num))
 }
 
+//UnmarshalTransaction is a function of converting bytes to type UInt8.
+func (num *UInt8) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	var v uint8
+	if err := decoder.DecodeNumber(&v); err != nil {
+		return err
+	}
+	*num = UInt8(v)
+	return nil
+}
+
 //UInt16 type from parameter JSON
 type UInt16 uint16
 
@@ -83,6 +93,16 @@ func (num UInt16) MarshalTransaction(encoder *transaction.Encoder) error {
 	return encoder.EncodeNumber(uint16(num))
 }
 
+//UnmarshalTransaction is a function of converting bytes to type UInt16.
+func (num *UInt16) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	var v uint16
+	if err := decoder.DecodeNumber(&v); err != nil {
+		return err
+	}
+	*num = UInt16(v)
+	return nil
+}
+
 //UInt32 type from parameter JSON
 type UInt32 uint32
 
@@ -102,6 +122,16 @@ func (num UInt32) MarshalTransaction(encoder *transaction.Encoder) error {
 	return encoder.EncodeNumber(uint32(num))
 }
 
+//UnmarshalTransaction is a function of converting bytes to type UInt32.
+func (num *UInt32) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	var v uint32
+	if err := decoder.DecodeNumber(&v); err != nil {
+		return err
+	}
+	*num = UInt32(v)
+	return nil
+}
+
 //UInt64 type from parameter JSON
 type UInt64 uint64
 
@@ -120,3 +150,13 @@ func (num *UInt64) UnmarshalJSON(data []byte) error {
 func (num UInt64) MarshalTransaction(encoder *transaction.Encoder) error {
 	return encoder.EncodeNumber(uint64(num))
 }
+
+//UnmarshalTransaction is a function of converting bytes to type UInt64.
+func (num *UInt64) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	var v uint64
+	if err := decoder.DecodeNumber(&v); err != nil {
+		return err
+	}
+	*num = UInt64(v)
+	return nil
+}
@@ -1,20 +1,18 @@
 package util
 
 import (
-	"fmt"
-	"strconv"
-	"strings"
+	"github.com/thanhxeon2470/beowulf-go/types"
 )
 
-func ParseBalance(balance string) (float64, error) {
+//ParseBalance parses a balance like '99.00000 SYMBOL' into an exact Asset, an empty balance is zero
+func ParseBalance(balance string) (*types.Asset, error) {
 	if balance == "" {
-		return 0, nil
+		return &types.Asset{}, nil
 	}
-	bl_arr := strings.Split(balance, " ")
-	bl,err := strconv.ParseFloat(bl_arr[0], 64)
-	return bl,err
+	return types.ParseAsset(balance)
 }
 
-func FormatBalance(balance float64, symbol string) string {
-	return fmt.Sprintf("%.5f", balance) + " " + symbol
+//FormatBalance formats the balance with its own precision, e.g. '99.00000 SYMBOL'
+func FormatBalance(balance *types.Asset) string {
+	return balance.String()
 }